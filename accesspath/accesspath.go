@@ -0,0 +1,251 @@
+// Package accesspath constructs and parses the access paths of Libra's global storage.
+//
+// An access path consists of an account address and a path within the account.
+// The path of a resource is the resource tag followed by the hash of the resource's StructTag,
+// the path of a code module is the code tag followed by the hash of the module's ModuleId.
+// Event handles are resource paths with an additional suffix like "/sent_events_count/".
+package accesspath
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/philippgille/libra-sdk-go/internal/canonical"
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+const (
+	// CodeTag is the first byte of the path of a code module.
+	CodeTag byte = 0
+	// ResourceTag is the first byte of the path of a resource.
+	ResourceTag byte = 1
+
+	// SentEventsSuffix is the suffix of the event handle path for sent payments.
+	SentEventsSuffix = "/sent_events_count/"
+	// ReceivedEventsSuffix is the suffix of the event handle path for received payments.
+	ReceivedEventsSuffix = "/received_events_count/"
+
+	// hashLen is the length of the StructTag and ModuleId hashes.
+	hashLen = 32
+)
+
+// CoreCodeAddress is the address of the account that published Libra's standard modules like LibraAccount.
+var CoreCodeAddress = make([]byte, 32)
+
+// AccountResourceTag is the StructTag of the LibraAccount.T resource,
+// which contains an account's balance, sequence number etc.
+var AccountResourceTag = StructTag{
+	Address: CoreCodeAddress,
+	Module:  "LibraAccount",
+	Name:    "T",
+}
+
+// StructTag identifies a struct type of a Move module, e.g. LibraAccount.T.
+type StructTag struct {
+	// Address of the account that published the module
+	Address []byte
+	// Name of the module
+	Module string
+	// Name of the struct
+	Name string
+	// Type parameters of generic structs
+	TypeParams []StructTag
+}
+
+// String formats the struct tag like "LibraAccount.T".
+// The address is only included if it's not the core code address, e.g. "0x0102...ff.MyModule.MyStruct".
+func (st StructTag) String() string {
+	s := st.Module + "." + st.Name
+	if !bytes.Equal(st.Address, CoreCodeAddress) {
+		s = "0x" + hex.EncodeToString(st.Address) + "." + s
+	}
+	if len(st.TypeParams) > 0 {
+		params := make([]string, len(st.TypeParams))
+		for i, param := range st.TypeParams {
+			params[i] = param.String()
+		}
+		s += "<" + strings.Join(params, ", ") + ">"
+	}
+	return s
+}
+
+// Hash returns the hash of the struct tag, which is part of a resource path.
+func (st StructTag) Hash() []byte {
+	s := &canonical.Serializer{}
+	st.serialize(s)
+	return hashing.Sum(hashing.AccessPathSalt, s.Result())
+}
+
+func (st StructTag) serialize(s *canonical.Serializer) {
+	s.Bytes(st.Address).String(st.Module).String(st.Name).U32(uint32(len(st.TypeParams)))
+	for _, param := range st.TypeParams {
+		param.serialize(s)
+	}
+}
+
+// ModuleHash returns the hash of the given module ID, which is part of a code path.
+func ModuleHash(moduleID *types.ModuleId) []byte {
+	s := &canonical.Serializer{}
+	s.Bytes(moduleID.GetAddress()).String(moduleID.GetName())
+	return hashing.Sum(hashing.AccessPathSalt, s.Result())
+}
+
+// ResourcePath returns the path of the resource with the given struct tag within an account.
+func ResourcePath(tag StructTag) []byte {
+	return append([]byte{ResourceTag}, tag.Hash()...)
+}
+
+// CodePath returns the path of the given module within the account that published it.
+func CodePath(moduleID *types.ModuleId) []byte {
+	return append([]byte{CodeTag}, ModuleHash(moduleID)...)
+}
+
+// Resource returns the access path of the resource with the given struct tag in the given account.
+func Resource(accountAddr []byte, tag StructTag) *types.AccessPath {
+	return &types.AccessPath{
+		Address: accountAddr,
+		Path:    ResourcePath(tag),
+	}
+}
+
+// Code returns the access path of the given module.
+func Code(moduleID *types.ModuleId) *types.AccessPath {
+	return &types.AccessPath{
+		Address: moduleID.GetAddress(),
+		Path:    CodePath(moduleID),
+	}
+}
+
+// EventHandle returns the access path of an event handle,
+// which is the resource path with the given suffix, e.g. SentEventsSuffix.
+// It's used for querying events.
+func EventHandle(accountAddr []byte, tag StructTag, suffix string) *types.AccessPath {
+	return &types.AccessPath{
+		Address: accountAddr,
+		Path:    append(ResourcePath(tag), suffix...),
+	}
+}
+
+// SentEvents returns the access path of the given account's sent payment events.
+func SentEvents(accountAddr []byte) *types.AccessPath {
+	return EventHandle(accountAddr, AccountResourceTag, SentEventsSuffix)
+}
+
+// ReceivedEvents returns the access path of the given account's received payment events.
+func ReceivedEvents(accountAddr []byte) *types.AccessPath {
+	return EventHandle(accountAddr, AccountResourceTag, ReceivedEventsSuffix)
+}
+
+// Known struct tags and modules, by their hex encoded hash.
+// They're used to give parsed paths a human-readable form.
+var (
+	knownLock      sync.RWMutex
+	knownResources = map[string]StructTag{}
+	knownModules   = map[string]*types.ModuleId{}
+)
+
+func init() {
+	RegisterStructTag(AccountResourceTag)
+	for _, name := range []string{"LibraAccount", "LibraCoin"} {
+		RegisterModule(&types.ModuleId{Address: CoreCodeAddress, Name: name})
+	}
+}
+
+// RegisterStructTag makes the given struct tag known to Parse(...),
+// so that parsed resource paths contain the struct tag instead of only its hash.
+func RegisterStructTag(tag StructTag) {
+	knownLock.Lock()
+	defer knownLock.Unlock()
+	knownResources[hex.EncodeToString(tag.Hash())] = tag
+}
+
+// RegisterModule makes the given module known to Parse(...),
+// so that parsed code paths contain the module ID instead of only its hash.
+func RegisterModule(moduleID *types.ModuleId) {
+	knownLock.Lock()
+	defer knownLock.Unlock()
+	knownModules[hex.EncodeToString(ModuleHash(moduleID))] = moduleID
+}
+
+// Path is a parsed access path.
+type Path struct {
+	// Address of the account
+	Address []byte
+	// Tag is either CodeTag or ResourceTag
+	Tag byte
+	// Hash of the StructTag or ModuleId
+	Hash []byte
+	// Resource is the struct tag of the resource, if the path is a resource path and the struct tag is known.
+	Resource *StructTag
+	// Module is the module ID, if the path is a code path and the module is known.
+	Module *types.ModuleId
+	// Suffix is the part of the path after the hash, e.g. SentEventsSuffix.
+	Suffix string
+}
+
+// Parse parses an access path.
+// For known struct tags and modules (see RegisterStructTag(...) and RegisterModule(...)),
+// the returned Path contains them in addition to their hash.
+func Parse(ap *types.AccessPath) (Path, error) {
+	path := ap.GetPath()
+	if len(path) < 1+hashLen {
+		return Path{}, errors.New("The access path is too short")
+	}
+	result := Path{
+		Address: ap.GetAddress(),
+		Tag:     path[0],
+		Hash:    path[1 : 1+hashLen],
+		Suffix:  string(path[1+hashLen:]),
+	}
+	hashString := hex.EncodeToString(result.Hash)
+	knownLock.RLock()
+	defer knownLock.RUnlock()
+	switch result.Tag {
+	case ResourceTag:
+		if tag, ok := knownResources[hashString]; ok {
+			result.Resource = &tag
+		}
+	case CodeTag:
+		if result.Suffix != "" {
+			return Path{}, errors.New("The code path contains unexpected data after the module hash")
+		}
+		if moduleID, ok := knownModules[hashString]; ok {
+			result.Module = moduleID
+		}
+	default:
+		return Path{}, fmt.Errorf("Unknown access path tag: %v", result.Tag)
+	}
+	return result, nil
+}
+
+// String formats the path in a human-readable form,
+// e.g. "0x8cd3...0969/resource/LibraAccount.T/sent_events_count/".
+// Unknown struct tags and modules are formatted as their hex encoded hash.
+func (p Path) String() string {
+	s := "0x" + hex.EncodeToString(p.Address)
+	switch p.Tag {
+	case ResourceTag:
+		s += "/resource/"
+		if p.Resource != nil {
+			s += p.Resource.String()
+		} else {
+			s += hex.EncodeToString(p.Hash)
+		}
+	case CodeTag:
+		s += "/code/"
+		if p.Module != nil {
+			s += p.Module.GetName()
+		} else {
+			s += hex.EncodeToString(p.Hash)
+		}
+	}
+	if p.Suffix != "" && !strings.HasPrefix(p.Suffix, "/") {
+		s += "/"
+	}
+	return s + p.Suffix
+}
@@ -0,0 +1,122 @@
+package accesspath_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+const (
+	// The key of the account resource in account state blobs returned by Libra validator nodes
+	accResourcePath = "01217da6c6b3e19f1825cfb2676daecce3bf3de03cf26647c78df00b371b25cc97"
+	testAcc1        = "8cd377191fe0ef113455c8e8d769f0c0147d5bb618bf195c0af31a05fbfd0969"
+)
+
+// TestResourcePath tests if accesspath.ResourcePath(...) computes the same path as Libra for the account resource.
+func TestResourcePath(t *testing.T) {
+	path := accesspath.ResourcePath(accesspath.AccountResourceTag)
+	if hex.EncodeToString(path) != accResourcePath {
+		t.Fatalf("Expected %v, but was %x", accResourcePath, path)
+	}
+}
+
+// TestEventHandle tests if the event handle paths consist of the account resource path and the suffix.
+func TestEventHandle(t *testing.T) {
+	addr, err := hex.DecodeString(testAcc1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPrefix, err := hex.DecodeString(accResourcePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ap := accesspath.SentEvents(addr)
+	if !bytes.Equal(ap.Address, addr) {
+		t.Fatal("ap.Address != account address")
+	}
+	if !bytes.Equal(ap.Path, append(expectedPrefix, "/sent_events_count/"...)) {
+		t.Fatalf("Unexpected path: %x", ap.Path)
+	}
+
+	ap = accesspath.ReceivedEvents(addr)
+	if !bytes.Equal(ap.Path, append(expectedPrefix, "/received_events_count/"...)) {
+		t.Fatalf("Unexpected path: %x", ap.Path)
+	}
+}
+
+// TestParse tests if accesspath.Parse(...) works correctly for known and unknown resources and modules.
+func TestParse(t *testing.T) {
+	addr, err := hex.DecodeString(testAcc1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := accesspath.Parse(accesspath.SentEvents(addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.Tag != accesspath.ResourceTag {
+		t.Fatalf("Expected tag %v, but was %v", accesspath.ResourceTag, path.Tag)
+	}
+	if path.Resource == nil || path.Resource.String() != "LibraAccount.T" {
+		t.Fatalf("Expected resource LibraAccount.T, but was %v", path.Resource)
+	}
+	if path.Suffix != accesspath.SentEventsSuffix {
+		t.Fatalf("Expected suffix %v, but was %v", accesspath.SentEventsSuffix, path.Suffix)
+	}
+	expected := "0x" + testAcc1 + "/resource/LibraAccount.T/sent_events_count/"
+	if path.String() != expected {
+		t.Fatalf("Expected %v, but was %v", expected, path.String())
+	}
+
+	// Unknown resource
+	unknownTag := accesspath.StructTag{Address: addr, Module: "MyModule", Name: "MyStruct"}
+	path, err = accesspath.Parse(accesspath.Resource(addr, unknownTag))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.Resource != nil {
+		t.Fatalf("Expected no resource, but was %v", path.Resource)
+	}
+	expected = "0x" + testAcc1 + "/resource/" + hex.EncodeToString(unknownTag.Hash())
+	if path.String() != expected {
+		t.Fatalf("Expected %v, but was %v", expected, path.String())
+	}
+	// After registering it it's known
+	accesspath.RegisterStructTag(unknownTag)
+	defer accesspath.UnregisterStructTag(unknownTag)
+	path, err = accesspath.Parse(accesspath.Resource(addr, unknownTag))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "0x" + testAcc1 + "/resource/0x" + testAcc1 + ".MyModule.MyStruct"
+	if path.String() != expected {
+		t.Fatalf("Expected %v, but was %v", expected, path.String())
+	}
+
+	// Known module
+	moduleID := &types.ModuleId{Address: accesspath.CoreCodeAddress, Name: "LibraCoin"}
+	path, err = accesspath.Parse(accesspath.Code(moduleID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "0x" + hex.EncodeToString(accesspath.CoreCodeAddress) + "/code/LibraCoin"
+	if path.String() != expected {
+		t.Fatalf("Expected %v, but was %v", expected, path.String())
+	}
+
+	// Invalid paths
+	invalid := []*types.AccessPath{
+		{Address: addr, Path: []byte{accesspath.ResourceTag, 1, 2, 3}},
+		{Address: addr, Path: append([]byte{2}, make([]byte, 32)...)},
+	}
+	for _, ap := range invalid {
+		if _, err = accesspath.Parse(ap); err == nil {
+			t.Fatalf("Expected an error when parsing %x", ap.Path)
+		}
+	}
+}
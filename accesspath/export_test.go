@@ -0,0 +1,12 @@
+package accesspath
+
+import (
+	"encoding/hex"
+)
+
+// UnregisterStructTag reverts RegisterStructTag(...), so that tests don't leave the struct tags they registered behind.
+func UnregisterStructTag(tag StructTag) {
+	knownLock.Lock()
+	defer knownLock.Unlock()
+	delete(knownResources, hex.EncodeToString(tag.Hash()))
+}
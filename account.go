@@ -4,15 +4,16 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-)
 
-const (
-	// See https://github.com/perfectmak/libra-core/blob/6dc45f7b72aee0162d52a9d53d99cdae1adae6f1/lib/constants/PathValues.ts
-	accResourceKey = "01217da6c6b3e19f1825cfb2676daecce3bf3de03cf26647c78df00b371b25cc97"
+	"github.com/philippgille/libra-sdk-go/accesspath"
 )
 
+// accResourceKey is the hex encoded key of the account resource in the account state blob.
+var accResourceKey = hex.EncodeToString(accesspath.ResourcePath(accesspath.AccountResourceTag))
+
 // AccountState represents the state of an account.
 type AccountState struct {
 	// The whole account state as raw bytes
@@ -22,6 +23,55 @@ type AccountState struct {
 	// TODO: AccountEvents?
 }
 
+// accountStateJSON is the JSON representation of an AccountState.
+type accountStateJSON struct {
+	Blob            hexBytes        `json:"blob"`
+	AccountResource AccountResource `json:"account_resource"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The blob is encoded as "0x"-prefixed hex string.
+func (as AccountState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(accountStateJSON{
+		Blob:            as.Blob,
+		AccountResource: as.AccountResource,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (as *AccountState) UnmarshalJSON(data []byte) error {
+	var v accountStateJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*as = AccountState{
+		Blob:            v.Blob,
+		AccountResource: v.AccountResource,
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text form is the "0x"-prefixed hex encoded account state blob.
+func (as AccountState) MarshalText() ([]byte, error) {
+	return hexBytes(as.Blob).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes a (optionally "0x"-prefixed) hex encoded account state blob.
+func (as *AccountState) UnmarshalText(text []byte) error {
+	var blob hexBytes
+	if err := blob.UnmarshalText(text); err != nil {
+		return err
+	}
+	decoded, err := FromAccountStateBlob(blob)
+	if err != nil {
+		return err
+	}
+	*as = decoded
+	return nil
+}
+
 // FromAccountStateBlob converts an account state blob into an object of the AccountState struct.
 func FromAccountStateBlob(accountStateBlob []byte) (AccountState, error) {
 	result := AccountState{
@@ -93,22 +143,99 @@ func FromAccountStateBlob(accountStateBlob []byte) (AccountState, error) {
 // AccountResource represents an account with its balance etc.
 type AccountResource struct {
 	AuthKey        []byte
-	Balance        uint64
+	Balance        Amount
 	ReceivedEvents uint64
 	SentEvents     uint64
 	SequenceNo     uint64
 }
 
-// String formats the account state similarly to the Libra CLI.
-// Numbers are formatted as string because the numbers are uint64,
+// String formats the account resource similarly to the Libra CLI.
+// It's the same as the JSON encoding, see MarshalJSON().
+func (ar AccountResource) String() string {
+	b, err := json.Marshal(ar)
+	if err != nil {
+		return fmt.Sprintf("%#v", ar)
+	}
+	return string(b)
+}
+
+// accountResourceJSON is the JSON representation of an AccountResource.
+// The field names are the ones used by the Libra CLI.
+// Numbers are encoded as string because the numbers are uint64,
 // whose max value exceeds JSON's "safe integer",
 // which can lead to parsing errors.
 //
 // Info about JSON's "safe integer":
 // https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Global_Objects/Number/MAX_SAFE_INTEGER
-func (ar AccountResource) String() string {
-	return fmt.Sprintf("{\"authentication_key\": \"0x%x\", \"balance\": \"%d\", \"received_events_count\": \"%d\", \"sent_events_count\": \"%d\", \"sequence_number\": \"%d\"}",
-		ar.AuthKey, ar.Balance, ar.ReceivedEvents, ar.SentEvents, ar.SequenceNo)
+type accountResourceJSON struct {
+	AuthKey        hexBytes `json:"authentication_key"`
+	Balance        Amount   `json:"balance"`
+	ReceivedEvents uint64   `json:"received_events_count,string"`
+	SentEvents     uint64   `json:"sent_events_count,string"`
+	SequenceNo     uint64   `json:"sequence_number,string"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The auth key is encoded as "0x"-prefixed hex string and all numbers are encoded as string.
+func (ar AccountResource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(accountResourceJSON{
+		AuthKey:        ar.AuthKey,
+		Balance:        ar.Balance,
+		ReceivedEvents: ar.ReceivedEvents,
+		SentEvents:     ar.SentEvents,
+		SequenceNo:     ar.SequenceNo,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ar *AccountResource) UnmarshalJSON(data []byte) error {
+	var v accountResourceJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*ar = AccountResource{
+		AuthKey:        v.AuthKey,
+		Balance:        v.Balance,
+		ReceivedEvents: v.ReceivedEvents,
+		SentEvents:     v.SentEvents,
+		SequenceNo:     v.SequenceNo,
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text form is the "0x"-prefixed hex encoded account resource blob.
+func (ar AccountResource) MarshalText() ([]byte, error) {
+	return hexBytes(ar.ToBlob()).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes a (optionally "0x"-prefixed) hex encoded account resource blob.
+func (ar *AccountResource) UnmarshalText(text []byte) error {
+	var blob hexBytes
+	if err := blob.UnmarshalText(text); err != nil {
+		return err
+	}
+	decoded, err := FromAccountResourceBlob(blob)
+	if err != nil {
+		return err
+	}
+	*ar = decoded
+	return nil
+}
+
+// ToBlob encodes the account resource into the blob format that's used in the account state.
+// It's the inverse of FromAccountResourceBlob().
+func (ar AccountResource) ToBlob() []byte {
+	buf := new(bytes.Buffer)
+	// Writes to a bytes.Buffer don't fail, so we can ignore the errors.
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(ar.AuthKey)))
+	buf.Write(ar.AuthKey)
+	_ = binary.Write(buf, binary.LittleEndian, ar.Balance.MicroLibra())
+	_ = binary.Write(buf, binary.LittleEndian, ar.ReceivedEvents)
+	_ = binary.Write(buf, binary.LittleEndian, ar.SentEvents)
+	_ = binary.Write(buf, binary.LittleEndian, ar.SequenceNo)
+	return buf.Bytes()
 }
 
 // FromAccountResourceBlob converts an account resource blob into an object of the AccountState struct.
@@ -135,7 +262,7 @@ func FromAccountResourceBlob(accountResourceBlob []byte) (AccountResource, error
 	if err != nil {
 		return result, err
 	}
-	result.Balance = balance
+	result.Balance = Amount(balance)
 
 	var receivedEvents uint64
 	err = binary.Read(r, binary.LittleEndian, &receivedEvents)
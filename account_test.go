@@ -3,6 +3,7 @@ package libra_test
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -81,7 +82,7 @@ func TestAccountResourceDecoding(t *testing.T) {
 	if !bytes.Equal(accRes.AuthKey, expectedAuthKey) {
 		t.Fatal("accRes.AuthKey != expected auth key")
 	}
-	if accRes.Balance != uint64(62500000) {
+	if accRes.Balance != libra.Amount(62500000) {
 		t.Fatal("accRes.Balance != 62500000")
 	}
 	if accRes.ReceivedEvents != uint64(1) {
@@ -94,3 +95,93 @@ func TestAccountResourceDecoding(t *testing.T) {
 		t.Fatal("accRes.SequenceNo != 4")
 	}
 }
+
+// TestAccountResourceJSON tests if the JSON encoding of libra.AccountResource matches the Libra CLI's field names
+// and if it can be decoded again.
+func TestAccountResourceJSON(t *testing.T) {
+	testAccRes, err := hex.DecodeString(testAcc1ResString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accRes, err := libra.FromAccountResourceBlob(testAccRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(accRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"authentication_key":"0x` + testAcc1AuthKey + `","balance":"62500000","received_events_count":"1","sent_events_count":"4","sequence_number":"4"}`
+	if string(b) != expected {
+		t.Fatalf("Expected %v, but was %v", expected, string(b))
+	}
+	if accRes.String() != expected {
+		t.Fatalf("Expected %v, but was %v", expected, accRes.String())
+	}
+
+	var decoded libra.AccountResource
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(decoded, accRes); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+// TestAccountStateJSON tests if libra.AccountState can be encoded to JSON and text and decoded again.
+func TestAccountStateJSON(t *testing.T) {
+	testAccState, err := hex.DecodeString(testAcc1StateString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accState, err := libra.FromAccountStateBlob(testAccState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(accState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded libra.AccountState
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(decoded, accState); diff != nil {
+		t.Fatal(diff)
+	}
+
+	text, err := accState.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "0x"+testAcc1StateString {
+		t.Fatalf("Expected %v, but was %v", "0x"+testAcc1StateString, string(text))
+	}
+	decoded = libra.AccountState{}
+	err = decoded.UnmarshalText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(decoded, accState); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+// TestAccountResourceToBlob tests if libra.AccountResource.ToBlob() is the inverse of libra.FromAccountResourceBlob(...).
+func TestAccountResourceToBlob(t *testing.T) {
+	testAccRes, err := hex.DecodeString(testAcc1ResString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accRes, err := libra.FromAccountResourceBlob(testAccRes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(accRes.ToBlob(), testAccRes) {
+		t.Fatal("accRes.ToBlob() != original account resource blob")
+	}
+}
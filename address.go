@@ -0,0 +1,69 @@
+package libra
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// AccountAddressLength is the length of a Libra account address in bytes.
+const AccountAddressLength = 32
+
+// AccountAddress is the address of a Libra account.
+//
+// Its string and text encoding is the hex encoded address without "0x" prefix,
+// like the addresses in the Libra CLI.
+type AccountAddress [AccountAddressLength]byte
+
+// AssociationAddress is the address of the Libra Association's account, which mints Libra Coins,
+// e.g. for the faucet of the testnet. In the Libra version of the SDK's protobuf definitions it's the zero address.
+var AssociationAddress = AccountAddress{}
+
+// ParseAccountAddress parses a hex encoded account address.
+// The "0x" prefix is optional.
+func ParseAccountAddress(s string) (AccountAddress, error) {
+	var result AccountAddress
+	b, err := decodeHex(s)
+	if err != nil {
+		return result, err
+	}
+	if len(b) != AccountAddressLength {
+		return result, fmt.Errorf("Invalid account address length: %v bytes instead of %v", len(b), AccountAddressLength)
+	}
+	copy(result[:], b)
+	return result, nil
+}
+
+// AccountAddressFromBytes converts a byte slice into an AccountAddress.
+func AccountAddressFromBytes(b []byte) (AccountAddress, error) {
+	var result AccountAddress
+	if len(b) != AccountAddressLength {
+		return result, fmt.Errorf("Invalid account address length: %v bytes instead of %v", len(b), AccountAddressLength)
+	}
+	copy(result[:], b)
+	return result, nil
+}
+
+// String returns the hex encoded address.
+func (a AccountAddress) String() string {
+	return hex.EncodeToString(a[:])
+}
+
+// Bytes returns the address as byte slice.
+func (a AccountAddress) Bytes() []byte {
+	return a[:]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a AccountAddress) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *AccountAddress) UnmarshalText(text []byte) error {
+	parsed, err := ParseAccountAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,180 @@
+package libra
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MicroLibra is the smallest unit of Libra Coins.
+	MicroLibra Amount = 1
+	// Libra is one Libra Coin, which is 1,000,000 micro-libra.
+	Libra Amount = 1000000
+
+	// libraDecimals is the number of decimal places of Libra Coins when formatted as micro-libra.
+	libraDecimals = 6
+	// libraSymbol is an optional suffix of decimal amounts like "62.5 LBR".
+	libraSymbol = "LBR"
+)
+
+var (
+	// ErrAmountOverflow is returned when the result of an arithmetic operation
+	// on Amounts exceeds the max value of an Amount.
+	ErrAmountOverflow = errors.New("Amount overflow")
+	// ErrAmountUnderflow is returned when the result of an arithmetic operation
+	// on Amounts would be negative.
+	ErrAmountUnderflow = errors.New("Amount underflow")
+)
+
+// Amount is an amount of Libra Coins in micro-libra.
+// It's used for balances, transfer amounts and gas fees.
+//
+// Its JSON and text encoding is the number of micro-libra as string,
+// like the balance in the Libra CLI's JSON output.
+// For the decimal representation in Libra (e.g. "62.5") use String() and ParseAmount(...).
+type Amount uint64
+
+// ParseAmount parses a decimal amount of Libra Coins like "62.5" or "62.5 LBR" into an Amount.
+// Up to 6 decimal places are allowed, which is the precision of micro-libra.
+func ParseAmount(s string) (Amount, error) {
+	trimmed := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), libraSymbol))
+	if trimmed == "" {
+		return 0, fmt.Errorf("Invalid amount %q", s)
+	}
+	intPart := trimmed
+	fracPart := ""
+	if i := strings.IndexByte(trimmed, '.'); i >= 0 {
+		intPart = trimmed[:i]
+		fracPart = trimmed[i+1:]
+	}
+	if len(fracPart) > libraDecimals {
+		return 0, fmt.Errorf("Invalid amount %q: more than %v decimal places", s, libraDecimals)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	libras, err := parseDigits(intPart)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid amount %q: %v", s, err)
+	}
+	var micros uint64
+	if fracPart != "" {
+		// Pad the fraction to micro-libra, e.g. "5" => "500000"
+		fracPart += strings.Repeat("0", libraDecimals-len(fracPart))
+		micros, err = parseDigits(fracPart)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid amount %q: %v", s, err)
+		}
+	}
+	result, err := Amount(libras).Mul(uint64(Libra))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid amount %q: %v", s, err)
+	}
+	return result.Add(Amount(micros))
+}
+
+// parseDigits parses a string of decimal digits.
+// Other than strconv.ParseUint it doesn't allow signs or underscores.
+func parseDigits(s string) (uint64, error) {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("Unexpected character %q", r)
+		}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// String formats the amount as decimal number of Libra Coins, e.g. "62.5".
+// Trailing zeros of the decimal places are omitted.
+func (a Amount) String() string {
+	libras := uint64(a / Libra)
+	micros := uint64(a % Libra)
+	if micros == 0 {
+		return strconv.FormatUint(libras, 10)
+	}
+	frac := fmt.Sprintf("%06d", micros)
+	return strconv.FormatUint(libras, 10) + "." + strings.TrimRight(frac, "0")
+}
+
+// MicroLibra returns the amount in micro-libra.
+func (a Amount) MicroLibra() uint64 {
+	return uint64(a)
+}
+
+// Add returns a + b.
+// ErrAmountOverflow is returned if the result exceeds the max value of an Amount.
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum, carry := bits.Add64(uint64(a), uint64(b), 0)
+	if carry != 0 {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(sum), nil
+}
+
+// Sub returns a - b.
+// ErrAmountUnderflow is returned if b is greater than a.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b > a {
+		return 0, ErrAmountUnderflow
+	}
+	return a - b, nil
+}
+
+// Mul returns a * n.
+// ErrAmountOverflow is returned if the result exceeds the max value of an Amount.
+func (a Amount) Mul(n uint64) (Amount, error) {
+	hi, lo := bits.Mul64(uint64(a), n)
+	if hi != 0 {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(lo), nil
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and +1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text form is the number of micro-libra.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(a), 10)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes a number of micro-libra.
+func (a *Amount) UnmarshalText(text []byte) error {
+	micros, err := parseDigits(string(text))
+	if err != nil {
+		return fmt.Errorf("Invalid amount %q: %v", text, err)
+	}
+	*a = Amount(micros)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The amount is encoded as string with the number of micro-libra, e.g. "62500000".
+// See accountResourceJSON for why numbers are encoded as string.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.FormatUint(uint64(a), 10))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts the number of micro-libra as string as well as JSON number.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	return a.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,118 @@
+package libra_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// TestParseAmount tests if libra.ParseAmount(...) works correctly.
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected libra.Amount
+	}{
+		{"0", 0},
+		{"1", libra.Libra},
+		{"62.5", 62500000},
+		{"62.5 LBR", 62500000},
+		{"0.000001", libra.MicroLibra},
+		{".5", 500000},
+		{"18446744073709.551615", libra.Amount(math.MaxUint64)},
+	}
+	for _, test := range tests {
+		a, err := libra.ParseAmount(test.in)
+		if err != nil {
+			t.Fatalf("Error parsing %q: %v", test.in, err)
+		}
+		if a != test.expected {
+			t.Fatalf("Expected %v for %q, but was %v", uint64(test.expected), test.in, uint64(a))
+		}
+	}
+
+	invalid := []string{"", "LBR", "-1", "+1", "1.0000001", "1e6", "1.2.3", "18446744073709.551616", "18446744073710"}
+	for _, in := range invalid {
+		if _, err := libra.ParseAmount(in); err == nil {
+			t.Fatalf("Expected an error when parsing %q", in)
+		}
+	}
+}
+
+// TestAmountString tests if libra.Amount is formatted as decimal number of Libra Coins.
+func TestAmountString(t *testing.T) {
+	tests := map[libra.Amount]string{
+		0:                            "0",
+		libra.MicroLibra:             "0.000001",
+		62500000:                     "62.5",
+		100 * libra.Libra:            "100",
+		libra.Amount(math.MaxUint64): "18446744073709.551615",
+	}
+	for a, expected := range tests {
+		if a.String() != expected {
+			t.Fatalf("Expected %v, but was %v", expected, a.String())
+		}
+	}
+}
+
+// TestAmountArithmetic tests if the arithmetic operations of libra.Amount detect overflows and underflows.
+func TestAmountArithmetic(t *testing.T) {
+	a, err := libra.Libra.Add(libra.MicroLibra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != 1000001 {
+		t.Fatalf("Expected 1000001, but was %v", uint64(a))
+	}
+	if _, err = libra.Amount(math.MaxUint64).Add(libra.MicroLibra); err != libra.ErrAmountOverflow {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAmountOverflow, err)
+	}
+
+	a, err = libra.Libra.Sub(libra.MicroLibra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != 999999 {
+		t.Fatalf("Expected 999999, but was %v", uint64(a))
+	}
+	if _, err = libra.MicroLibra.Sub(libra.Libra); err != libra.ErrAmountUnderflow {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAmountUnderflow, err)
+	}
+
+	a, err = libra.Libra.Mul(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != 3*libra.Libra {
+		t.Fatalf("Expected 3000000, but was %v", uint64(a))
+	}
+	if _, err = libra.Amount(math.MaxUint64).Mul(2); err != libra.ErrAmountOverflow {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAmountOverflow, err)
+	}
+
+	if libra.MicroLibra.Cmp(libra.Libra) != -1 || libra.Libra.Cmp(libra.MicroLibra) != 1 || libra.Libra.Cmp(libra.Libra) != 0 {
+		t.Fatal("Amount.Cmp(...) returned an unexpected result")
+	}
+}
+
+// TestAmountJSON tests if libra.Amount is encoded as string of micro-libra and can be decoded from strings and numbers.
+func TestAmountJSON(t *testing.T) {
+	b, err := json.Marshal(libra.Amount(62500000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"62500000"` {
+		t.Fatalf(`Expected "62500000", but was %v`, string(b))
+	}
+	for _, in := range []string{`"62500000"`, `62500000`} {
+		var a libra.Amount
+		err = json.Unmarshal([]byte(in), &a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a != 62500000 {
+			t.Fatalf("Expected 62500000, but was %v", uint64(a))
+		}
+	}
+}
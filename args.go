@@ -0,0 +1,85 @@
+package libra
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// U64Arg creates a U64 transaction argument, e.g. for an amount in micro-libra.
+func U64Arg(v uint64) TransactionArgument {
+	return TransactionArgument{Type: types.TransactionArgument_U64, Data: encodeU64(v)}
+}
+
+// AddressArg creates an ADDRESS transaction argument.
+func AddressArg(addr AccountAddress) TransactionArgument {
+	return TransactionArgument{Type: types.TransactionArgument_ADDRESS, Data: addr.Bytes()}
+}
+
+// StringArg creates a STRING transaction argument. The string must be valid UTF-8.
+func StringArg(s string) TransactionArgument {
+	return TransactionArgument{Type: types.TransactionArgument_STRING, Data: []byte(s)}
+}
+
+// BytesArg creates a BYTEARRAY transaction argument.
+func BytesArg(b []byte) TransactionArgument {
+	return TransactionArgument{Type: types.TransactionArgument_BYTEARRAY, Data: b}
+}
+
+// ArgumentMismatchError is returned when the arguments of a program don't match the argument types of its script.
+// The Libra VM rejects such programs with the verification error TypeMismatch.
+type ArgumentMismatchError struct {
+	Script   string
+	Expected []types.TransactionArgument_ArgType
+	Actual   []types.TransactionArgument_ArgType
+}
+
+// Error implements the error interface.
+func (e ArgumentMismatchError) Error() string {
+	return fmt.Sprintf("The script %q expects the arguments (%v), but got (%v)", e.Script, formatArgTypes(e.Expected), formatArgTypes(e.Actual))
+}
+
+func formatArgTypes(argTypes []types.TransactionArgument_ArgType) string {
+	var result []string
+	for _, argType := range argTypes {
+		result = append(result, argType.String())
+	}
+	return strings.Join(result, ", ")
+}
+
+// CheckArguments checks if the arguments have the script's argument types and if their data is valid for their type,
+// see TransactionArgument.Decode(). An ArgumentMismatchError is returned if the types don't match.
+// Only the data is checked for scripts without ArgTypes.
+func (s Script) CheckArguments(args []TransactionArgument) error {
+	var actual []types.TransactionArgument_ArgType
+	for i, arg := range args {
+		if _, err := arg.Decode(); err != nil {
+			return fmt.Errorf("Invalid argument %v: %v", i, err)
+		}
+		actual = append(actual, arg.Type)
+	}
+	if s.ArgTypes == nil {
+		return nil
+	}
+	mismatch := len(actual) != len(s.ArgTypes)
+	for i := 0; !mismatch && i < len(actual); i++ {
+		mismatch = actual[i] != s.ArgTypes[i]
+	}
+	if mismatch {
+		return ArgumentMismatchError{
+			Script:   s.Name,
+			Expected: s.ArgTypes,
+			Actual:   actual,
+		}
+	}
+	return nil
+}
+
+// CheckArguments checks the program's arguments against the registered script with the same bytecode hash,
+// see Script.CheckArguments(...). For unregistered scripts, only the data of the arguments is checked.
+func (p Program) CheckArguments() error {
+	script, _ := ScriptByHash(hashing.SHA3(p.Code))
+	return script.CheckArguments(p.Arguments)
+}
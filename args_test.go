@@ -0,0 +1,137 @@
+package libra_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestArgConstructors tests if the arguments that are created with the typed constructors decode into the same values.
+func TestArgConstructors(t *testing.T) {
+	testCases := []struct {
+		arg          libra.TransactionArgument
+		expectedType types.TransactionArgument_ArgType
+		expected     interface{}
+	}{
+		{libra.U64Arg(1 << 40), types.TransactionArgument_U64, uint64(1 << 40)},
+		{libra.AddressArg(libra.AccountAddress{4}), types.TransactionArgument_ADDRESS, libra.AccountAddress{4}},
+		{libra.StringArg("föö"), types.TransactionArgument_STRING, "föö"},
+	}
+	for _, tc := range testCases {
+		if tc.arg.Type != tc.expectedType {
+			t.Fatalf("Expected type %v, but was %v", tc.expectedType, tc.arg.Type)
+		}
+		v, err := tc.arg.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != tc.expected {
+			t.Fatalf("Expected %v, but was %v", tc.expected, v)
+		}
+	}
+
+	arg := libra.BytesArg([]byte{1, 2})
+	b, err := arg.Decode()
+	if err != nil || arg.Type != types.TransactionArgument_BYTEARRAY || !bytes.Equal(b.([]byte), []byte{1, 2}) {
+		t.Fatalf("Expected the byte array, but was %v of type %v (error: %v)", b, arg.Type, err)
+	}
+
+	if _, err := libra.StringArg("\xff").Decode(); err == nil {
+		t.Fatal("Expected an error for invalid UTF-8")
+	}
+}
+
+// TestScriptCheckArguments tests if arguments with other types than the script's argument types are rejected.
+func TestScriptCheckArguments(t *testing.T) {
+	script := libra.Script{
+		Name:     "transfer_with_memo",
+		ArgTypes: []types.TransactionArgument_ArgType{types.TransactionArgument_ADDRESS, types.TransactionArgument_U64, types.TransactionArgument_STRING},
+	}
+	receiver := libra.AddressArg(libra.AccountAddress{2})
+	if err := script.CheckArguments([]libra.TransactionArgument{receiver, libra.U64Arg(5), libra.StringArg("memo")}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := [][]libra.TransactionArgument{
+		{receiver, libra.U64Arg(5)},
+		{libra.U64Arg(5), receiver, libra.StringArg("memo")},
+		{receiver, libra.U64Arg(5), libra.BytesArg([]byte("memo"))},
+		{receiver, libra.U64Arg(5), libra.StringArg("memo"), libra.U64Arg(1)},
+	}
+	for _, args := range testCases {
+		err := script.CheckArguments(args)
+		mismatchErr, ok := err.(libra.ArgumentMismatchError)
+		if !ok {
+			t.Fatalf("Expected a libra.ArgumentMismatchError, but was %v", err)
+		}
+		var actual []types.TransactionArgument_ArgType
+		for _, arg := range args {
+			actual = append(actual, arg.Type)
+		}
+		if diff := deep.Equal(mismatchErr.Actual, actual); diff != nil {
+			t.Fatal(diff)
+		}
+		if mismatchErr.Script != script.Name || len(mismatchErr.Expected) != 3 {
+			t.Fatalf("Unexpected error: %v", mismatchErr)
+		}
+	}
+
+	// Invalid data is detected, also for scripts without argument types
+	invalidU64 := libra.TransactionArgument{Type: types.TransactionArgument_U64, Data: []byte{1}}
+	for _, s := range []libra.Script{script, {Name: "custom"}} {
+		err := s.CheckArguments([]libra.TransactionArgument{receiver, invalidU64, libra.StringArg("memo")})
+		if _, ok := err.(libra.ArgumentMismatchError); ok || err == nil {
+			t.Fatalf("Expected an error for the invalid U64 argument, but was %v", err)
+		}
+	}
+	if err := (libra.Script{Name: "custom"}).CheckArguments([]libra.TransactionArgument{libra.U64Arg(1)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestNewScriptTransaction tests if NewScriptTransaction(...) checks the arguments against the registered script
+// and if libratest rejects programs with mismatching arguments like the VM.
+func TestNewScriptTransaction(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptPeerToPeerTransfer,
+		Code: []byte{1, 2, 3},
+	})
+	sender, receiver := libra.AccountAddress{1}, libra.AccountAddress{2}
+	rawTx, err := libra.NewScriptTransaction(sender, 0, libra.ScriptPeerToPeerTransfer,
+		[]libra.TransactionArgument{libra.AddressArg(receiver), libra.U64Arg(5)}, libra.DefaultFeePolicy.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rawTx.Program.CheckArguments(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = libra.NewScriptTransaction(sender, 0, libra.ScriptPeerToPeerTransfer,
+		[]libra.TransactionArgument{libra.U64Arg(5), libra.AddressArg(receiver)}, libra.DefaultFeePolicy.Default)
+	if _, ok := err.(libra.ArgumentMismatchError); !ok {
+		t.Fatalf("Expected a libra.ArgumentMismatchError, but was %v", err)
+	}
+
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{Balance: libra.Libra})
+	defer s.Close()
+	defer c.Close()
+	rawTx.Sender = addr
+	rawTx.Program.Arguments = []libra.TransactionArgument{libra.AddressArg(receiver)}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SendTx(libra.Transaction{RawBytes: rawTxBytes})
+	submitErr, ok := err.(libra.SubmitError)
+	if !ok {
+		t.Fatalf("Expected a libra.SubmitError, but was %v", err)
+	}
+	statusList := submitErr.VMStatus.GetVerification().GetStatusList()
+	if len(statusList) != 1 || statusList[0].GetErrorKind() != types.VMVerificationErrorKind_TypeMismatch {
+		t.Fatalf("Expected the verification error TypeMismatch, but was %v", err)
+	}
+}
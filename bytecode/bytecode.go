@@ -0,0 +1,233 @@
+// Package bytecode deserializes and disassembles compiled Move scripts and modules,
+// e.g. for showing what the script of a fetched transaction does.
+//
+// The binary format is the one of the Libra version the SDK's protos are from.
+// A binary starts with the magic bytes, the format version and a list of table headers,
+// followed by the contents of the tables. The tables contain the handles of modules, structs and functions,
+// the pools of constants (addresses, strings and byte arrays), the signatures of types, functions and locals,
+// and the definitions of structs, fields and functions. Indexes into tables are ULEB128 encoded.
+//
+// Errors in the binary format are returned as Error, with the BinaryError the Libra VM reports for the binary.
+package bytecode
+
+import (
+	"fmt"
+)
+
+// Magic is the first 8 bytes of every Move binary.
+var Magic = []byte("LIBRAVM\n")
+
+const (
+	// VersionMajor is the major version of the supported binary format.
+	VersionMajor = 1
+	// VersionMinor is the minor version of the supported binary format.
+	VersionMinor = 0
+	// AddressLength is the length of the account addresses in the address pool.
+	AddressLength = 32
+)
+
+// TableType is the kind of a table in the binary.
+type TableType byte
+
+// Table types of the binary format
+const (
+	ModuleHandlesTable      TableType = 0x1
+	StructHandlesTable      TableType = 0x2
+	FunctionHandlesTable    TableType = 0x3
+	AddressPoolTable        TableType = 0x4
+	StringPoolTable         TableType = 0x5
+	ByteArrayPoolTable      TableType = 0x6
+	MainTable               TableType = 0x7
+	StructDefsTable         TableType = 0x8
+	FieldDefsTable          TableType = 0x9
+	FunctionDefsTable       TableType = 0xA
+	TypeSignaturesTable     TableType = 0xB
+	FunctionSignaturesTable TableType = 0xC
+	LocalsSignaturesTable   TableType = 0xD
+)
+
+var tableTypeNames = map[TableType]string{
+	ModuleHandlesTable:      "module handles",
+	StructHandlesTable:      "struct handles",
+	FunctionHandlesTable:    "function handles",
+	AddressPoolTable:        "address pool",
+	StringPoolTable:         "string pool",
+	ByteArrayPoolTable:      "byte array pool",
+	MainTable:               "main",
+	StructDefsTable:         "struct definitions",
+	FieldDefsTable:          "field definitions",
+	FunctionDefsTable:       "function definitions",
+	TypeSignaturesTable:     "type signatures",
+	FunctionSignaturesTable: "function signatures",
+	LocalsSignaturesTable:   "locals signatures",
+}
+
+// String returns the name of the table type, like "string pool".
+func (t TableType) String() string {
+	if name, ok := tableTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown table type 0x%x", byte(t))
+}
+
+// Tags of the signatures in the signature tables
+const (
+	typeSignatureTag     byte = 0x1
+	functionSignatureTag byte = 0x2
+	localsSignatureTag   byte = 0x3
+)
+
+// Flags of the struct handles
+const (
+	resourceFlag     byte = 0x1
+	normalStructFlag byte = 0x2
+)
+
+// Flags of function definitions
+const (
+	// PublicFunction is the flag of functions that can be called from other modules and scripts.
+	PublicFunction byte = 0x1
+	// NativeFunction is the flag of functions that are implemented by the VM. They don't have code.
+	NativeFunction byte = 0x2
+)
+
+// SerializedType is the type of a SignatureToken.
+type SerializedType byte
+
+// Types of signature tokens
+const (
+	Bool             SerializedType = 0x1
+	U64              SerializedType = 0x2
+	String           SerializedType = 0x3
+	Address          SerializedType = 0x4
+	Reference        SerializedType = 0x5
+	MutableReference SerializedType = 0x6
+	Struct           SerializedType = 0x7
+	ByteArray        SerializedType = 0x8
+)
+
+// SignatureToken is the type of a field, argument, return value or local.
+type SignatureToken struct {
+	Type SerializedType
+	// StructHandle is the index of the struct handle, for the type Struct
+	StructHandle uint16
+	// Referenced is the referenced type, for the types Reference and MutableReference
+	Referenced *SignatureToken
+}
+
+// ModuleHandle references a module, which is published under the account with the address.
+// The first module handle of a binary is the module itself, or a placeholder for scripts.
+type ModuleHandle struct {
+	// Address is the index in the address pool
+	Address uint16
+	// Name is the index in the string pool
+	Name uint16
+}
+
+// StructHandle references a struct of a module.
+type StructHandle struct {
+	// Module is the index of the module handle
+	Module uint16
+	// Name is the index in the string pool
+	Name       uint16
+	IsResource bool
+}
+
+// FunctionHandle references a function of a module.
+type FunctionHandle struct {
+	// Module is the index of the module handle
+	Module uint16
+	// Name is the index in the string pool
+	Name uint16
+	// Signature is the index of the function signature
+	Signature uint16
+}
+
+// FunctionSignature contains the types of the return values and arguments of a function.
+type FunctionSignature struct {
+	ReturnTypes []SignatureToken
+	ArgTypes    []SignatureToken
+}
+
+// StructDefinition defines a struct of a module.
+// Its fields are the FieldCount field definitions starting at the index Fields.
+type StructDefinition struct {
+	// StructHandle is the index of the struct handle
+	StructHandle uint16
+	FieldCount   uint16
+	// Fields is the index of the first field definition
+	Fields uint16
+}
+
+// FieldDefinition defines a field of a struct.
+type FieldDefinition struct {
+	// Struct is the index of the struct handle of the struct the field belongs to
+	Struct uint16
+	// Name is the index in the string pool
+	Name uint16
+	// Signature is the index of the type signature
+	Signature uint16
+}
+
+// FunctionDefinition defines a function of a module, or the main function of a script.
+type FunctionDefinition struct {
+	// Function is the index of the function handle
+	Function uint16
+	// Flags is a combination of PublicFunction and NativeFunction
+	Flags byte
+	Code  CodeUnit
+}
+
+// IsPublic returns true if the function has the flag PublicFunction.
+func (f FunctionDefinition) IsPublic() bool {
+	return f.Flags&PublicFunction != 0
+}
+
+// IsNative returns true if the function has the flag NativeFunction.
+func (f FunctionDefinition) IsNative() bool {
+	return f.Flags&NativeFunction != 0
+}
+
+// CodeUnit is the code of a function.
+type CodeUnit struct {
+	MaxStackSize uint16
+	// Locals is the index of the locals signature. The arguments are the first locals.
+	Locals uint16
+	Code   []Instruction
+}
+
+// Instruction is a bytecode instruction.
+// The meaning of the operand depends on the opcode, see Opcode.
+type Instruction struct {
+	Opcode  Opcode
+	Operand uint64
+}
+
+// Tables are the tables that scripts and modules have in common.
+type Tables struct {
+	ModuleHandles      []ModuleHandle
+	StructHandles      []StructHandle
+	FunctionHandles    []FunctionHandle
+	TypeSignatures     []SignatureToken
+	FunctionSignatures []FunctionSignature
+	LocalsSignatures   [][]SignatureToken
+	StringPool         []string
+	ByteArrayPool      [][]byte
+	AddressPool        [][]byte
+}
+
+// Script is a compiled transaction script.
+type Script struct {
+	Tables
+	// Main is the function that's executed with the transaction's arguments
+	Main FunctionDefinition
+}
+
+// Module is a compiled module.
+// Its first module handle, struct handles and function handles reference the module itself.
+type Module struct {
+	Tables
+	StructDefs   []StructDefinition
+	FieldDefs    []FieldDefinition
+	FunctionDefs []FunctionDefinition
+}
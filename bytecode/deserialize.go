@@ -0,0 +1,451 @@
+package bytecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// Error is returned when a binary doesn't conform to the binary format.
+type Error struct {
+	// Kind is the deserialization status the Libra VM returns for the binary
+	Kind types.BinaryError
+	// Offset is the position in the binary where the error was detected
+	Offset  int
+	Message string
+}
+
+// Error implements the error interface.
+func (e Error) Error() string {
+	return fmt.Sprintf("Invalid Move binary: %v (%v at offset %v)", e.Message, e.Kind, e.Offset)
+}
+
+// DeserializeScript deserializes a compiled transaction script, e.g. the Program.Code of a transaction.
+func DeserializeScript(binary []byte) (Script, error) {
+	tables, err := readHeader(binary)
+	if err != nil {
+		return Script{}, err
+	}
+	for _, kind := range []TableType{StructDefsTable, FieldDefsTable, FunctionDefsTable} {
+		if r, ok := tables[kind]; ok {
+			return Script{}, Error{Kind: types.BinaryError_Malformed, Offset: r.base, Message: fmt.Sprintf("Scripts can't have %v", kind)}
+		}
+	}
+	r, ok := tables[MainTable]
+	if !ok {
+		return Script{}, Error{Kind: types.BinaryError_Malformed, Message: "The script doesn't have a main function"}
+	}
+	var result Script
+	if result.Tables, err = readCommonTables(tables); err != nil {
+		return Script{}, err
+	}
+	result.Main = r.functionDefinition()
+	if r.more() {
+		r.fail(types.BinaryError_Malformed, "The main table has more than one function")
+	}
+	if r.err != nil {
+		return Script{}, r.err
+	}
+	return result, nil
+}
+
+// DeserializeModule deserializes a compiled module, e.g. one of the Program.Modules of a transaction.
+func DeserializeModule(binary []byte) (Module, error) {
+	tables, err := readHeader(binary)
+	if err != nil {
+		return Module{}, err
+	}
+	if r, ok := tables[MainTable]; ok {
+		return Module{}, Error{Kind: types.BinaryError_Malformed, Offset: r.base, Message: "Modules can't have a main function"}
+	}
+	var result Module
+	if result.Tables, err = readCommonTables(tables); err != nil {
+		return Module{}, err
+	}
+	err = readTables(tables, []tableReader{
+		{StructDefsTable, func(r *reader) {
+			result.StructDefs = append(result.StructDefs, StructDefinition{
+				StructHandle: r.index(),
+				FieldCount:   r.index(),
+				Fields:       r.index(),
+			})
+		}},
+		{FieldDefsTable, func(r *reader) {
+			result.FieldDefs = append(result.FieldDefs, FieldDefinition{
+				Struct:    r.index(),
+				Name:      r.index(),
+				Signature: r.index(),
+			})
+		}},
+		{FunctionDefsTable, func(r *reader) {
+			result.FunctionDefs = append(result.FunctionDefs, r.functionDefinition())
+		}},
+	})
+	if err != nil {
+		return Module{}, err
+	}
+	return result, nil
+}
+
+// IsScript returns true if the binary has a main function, which only scripts have.
+// An error is returned if the header of the binary is invalid.
+func IsScript(binary []byte) (bool, error) {
+	tables, err := readHeader(binary)
+	if err != nil {
+		return false, err
+	}
+	_, ok := tables[MainTable]
+	return ok, nil
+}
+
+// readHeader checks the magic bytes and version of the binary and reads the table headers.
+// The tables must be non-empty, contiguous and follow directly after the table headers.
+// A reader for the contents of each table is returned.
+func readHeader(b []byte) (map[TableType]*reader, error) {
+	r := &reader{b: b, name: "header"}
+	magic := r.raw(len(Magic))
+	if r.err != nil {
+		return nil, r.err
+	}
+	if !bytes.Equal(magic, Magic) {
+		return nil, Error{Kind: types.BinaryError_BadMagic, Message: fmt.Sprintf("Unexpected magic bytes 0x%x", magic)}
+	}
+	major, minor := r.u8(), r.u8()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if major != VersionMajor || minor != VersionMinor {
+		return nil, Error{Kind: types.BinaryError_UnknownVersion, Offset: len(Magic), Message: fmt.Sprintf("Unsupported version %v.%v", major, minor)}
+	}
+
+	type tableHeader struct {
+		kind         TableType
+		offset, size uint32
+		headerOffset int
+	}
+	count := int(r.u8())
+	if r.err != nil {
+		return nil, r.err
+	}
+	var headers []tableHeader
+	seen := make(map[TableType]bool, count)
+	for i := 0; i < count; i++ {
+		h := tableHeader{headerOffset: r.pos}
+		h.kind, h.offset, h.size = TableType(r.u8()), r.u32(), r.u32()
+		if r.err != nil {
+			return nil, r.err
+		}
+		if _, ok := tableTypeNames[h.kind]; !ok {
+			return nil, Error{Kind: types.BinaryError_UnknownTableType, Offset: h.headerOffset, Message: fmt.Sprintf("Unknown table type 0x%x", byte(h.kind))}
+		}
+		if seen[h.kind] {
+			return nil, Error{Kind: types.BinaryError_DuplicateTable, Offset: h.headerOffset, Message: fmt.Sprintf("Duplicate %v table", h.kind)}
+		}
+		seen[h.kind] = true
+		headers = append(headers, h)
+	}
+
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].offset < headers[j].offset
+	})
+	result := make(map[TableType]*reader, len(headers))
+	next := uint64(r.pos)
+	for _, h := range headers {
+		end := uint64(h.offset) + uint64(h.size)
+		switch {
+		case h.size == 0:
+			return nil, Error{Kind: types.BinaryError_BadHeaderTable, Offset: h.headerOffset, Message: fmt.Sprintf("The %v table is empty", h.kind)}
+		case uint64(h.offset) != next:
+			return nil, Error{Kind: types.BinaryError_BadHeaderTable, Offset: h.headerOffset, Message: fmt.Sprintf("The %v table starts at offset %v instead of %v", h.kind, h.offset, next)}
+		case end > uint64(len(b)):
+			return nil, Error{Kind: types.BinaryError_BadHeaderTable, Offset: h.headerOffset, Message: fmt.Sprintf("The %v table exceeds the binary's length of %v bytes", h.kind, len(b))}
+		}
+		result[h.kind] = &reader{b: b[h.offset:end], base: int(h.offset), name: h.kind.String() + " table"}
+		next = end
+	}
+	if next != uint64(len(b)) {
+		return nil, Error{Kind: types.BinaryError_Malformed, Offset: int(next), Message: "Trailing bytes after the tables"}
+	}
+	return result, nil
+}
+
+// tableReader reads one entry of a table of the given type.
+type tableReader struct {
+	kind TableType
+	read func(r *reader)
+}
+
+// readTables reads the entries of each table until its end. Missing tables are skipped.
+func readTables(tables map[TableType]*reader, tableReaders []tableReader) error {
+	for _, tr := range tableReaders {
+		r, ok := tables[tr.kind]
+		if !ok {
+			continue
+		}
+		for r.more() {
+			tr.read(r)
+		}
+		if r.err != nil {
+			return r.err
+		}
+	}
+	return nil
+}
+
+// readCommonTables reads the tables that scripts and modules have in common.
+func readCommonTables(tables map[TableType]*reader) (Tables, error) {
+	var t Tables
+	err := readTables(tables, []tableReader{
+		{ModuleHandlesTable, func(r *reader) {
+			t.ModuleHandles = append(t.ModuleHandles, ModuleHandle{
+				Address: r.index(),
+				Name:    r.index(),
+			})
+		}},
+		{StructHandlesTable, func(r *reader) {
+			t.StructHandles = append(t.StructHandles, StructHandle{
+				Module:     r.index(),
+				Name:       r.index(),
+				IsResource: r.resourceFlag(),
+			})
+		}},
+		{FunctionHandlesTable, func(r *reader) {
+			t.FunctionHandles = append(t.FunctionHandles, FunctionHandle{
+				Module:    r.index(),
+				Name:      r.index(),
+				Signature: r.index(),
+			})
+		}},
+		{AddressPoolTable, func(r *reader) {
+			t.AddressPool = append(t.AddressPool, append([]byte(nil), r.raw(AddressLength)...))
+		}},
+		{StringPoolTable, func(r *reader) {
+			start := r.pos
+			s := r.raw(int(r.uleb(math.MaxUint32)))
+			if r.err == nil && !utf8.Valid(s) {
+				r.failAt(start, types.BinaryError_Malformed, "The string %v isn't valid UTF-8", len(t.StringPool))
+			}
+			t.StringPool = append(t.StringPool, string(s))
+		}},
+		{ByteArrayPoolTable, func(r *reader) {
+			t.ByteArrayPool = append(t.ByteArrayPool, append([]byte(nil), r.raw(int(r.uleb(math.MaxUint32)))...))
+		}},
+		{TypeSignaturesTable, func(r *reader) {
+			r.signatureTag(typeSignatureTag)
+			t.TypeSignatures = append(t.TypeSignatures, r.signatureToken())
+		}},
+		{FunctionSignaturesTable, func(r *reader) {
+			r.signatureTag(functionSignatureTag)
+			t.FunctionSignatures = append(t.FunctionSignatures, FunctionSignature{
+				ReturnTypes: r.signatureTokens(),
+				ArgTypes:    r.signatureTokens(),
+			})
+		}},
+		{LocalsSignaturesTable, func(r *reader) {
+			r.signatureTag(localsSignatureTag)
+			t.LocalsSignatures = append(t.LocalsSignatures, r.signatureTokens())
+		}},
+	})
+	return t, err
+}
+
+// reader reads the header or a table of a binary.
+// The first error that occurs is kept, subsequent reads return zero values.
+type reader struct {
+	b   []byte
+	pos int
+	// base is the offset of b in the binary
+	base int
+	// name of the header or table, for error messages
+	name string
+	err  error
+}
+
+// fail sets the error at the current position, unless an error already occurred.
+func (r *reader) fail(kind types.BinaryError, format string, args ...interface{}) {
+	r.failAt(r.pos, kind, format, args...)
+}
+
+// failAt sets the error at the given position, unless an error already occurred.
+func (r *reader) failAt(pos int, kind types.BinaryError, format string, args ...interface{}) {
+	if r.err == nil {
+		r.err = Error{Kind: kind, Offset: r.base + pos, Message: fmt.Sprintf(format, args...)}
+	}
+}
+
+// more returns true if there are unread bytes and no error occurred.
+func (r *reader) more() bool {
+	return r.err == nil && r.pos < len(r.b)
+}
+
+func (r *reader) raw(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.b)-r.pos {
+		r.fail(types.BinaryError_Malformed, "Unexpected end of the %v", r.name)
+		return nil
+	}
+	result := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return result
+}
+
+func (r *reader) u8() byte {
+	if b := r.raw(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) u16() uint16 {
+	if b := r.raw(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) u32() uint32 {
+	if b := r.raw(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) u64() uint64 {
+	if b := r.raw(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// uleb reads a ULEB128 encoded integer, which must not be greater than max.
+func (r *reader) uleb(max uint64) uint64 {
+	start := r.pos
+	var result uint64
+	for shift := uint(0); r.err == nil; shift += 7 {
+		b := r.u8()
+		if shift == 63 && b > 1 {
+			r.failAt(start, types.BinaryError_Malformed, "ULEB128 value overflows")
+			return 0
+		}
+		result |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	if r.err == nil && result > max {
+		r.failAt(start, types.BinaryError_Malformed, "ULEB128 value %v exceeds %v", result, max)
+	}
+	return result
+}
+
+// index reads a ULEB128 encoded table index.
+func (r *reader) index() uint16 {
+	return uint16(r.uleb(math.MaxUint16))
+}
+
+func (r *reader) resourceFlag() bool {
+	start := r.pos
+	flag := r.u8()
+	if r.err == nil && flag != resourceFlag && flag != normalStructFlag {
+		r.failAt(start, types.BinaryError_Malformed, "Invalid resource flag 0x%x", flag)
+	}
+	return flag == resourceFlag
+}
+
+// signatureTag reads the tag of a signature, which must be the expected one.
+func (r *reader) signatureTag(expected byte) {
+	start := r.pos
+	tag := r.u8()
+	if r.err != nil || tag == expected {
+		return
+	}
+	switch tag {
+	case typeSignatureTag, functionSignatureTag, localsSignatureTag:
+		r.failAt(start, types.BinaryError_UnexpectedSignatureType, "Unexpected signature type 0x%x in the %v", tag, r.name)
+	default:
+		r.failAt(start, types.BinaryError_UnknownSignatureType, "Unknown signature type 0x%x", tag)
+	}
+}
+
+// signatureTokens reads a list of signature tokens, prefixed with their count as single byte.
+func (r *reader) signatureTokens() []SignatureToken {
+	n := int(r.u8())
+	var result []SignatureToken
+	for i := 0; i < n && r.err == nil; i++ {
+		result = append(result, r.signatureToken())
+	}
+	return result
+}
+
+func (r *reader) signatureToken() SignatureToken {
+	start := r.pos
+	result := SignatureToken{Type: SerializedType(r.u8())}
+	if r.err != nil {
+		return SignatureToken{}
+	}
+	switch result.Type {
+	case Bool, U64, String, Address, ByteArray:
+	case Reference, MutableReference:
+		referenced := r.signatureToken()
+		result.Referenced = &referenced
+	case Struct:
+		result.StructHandle = r.index()
+	default:
+		r.failAt(start, types.BinaryError_UnknownSerializedType, "Unknown serialized type 0x%x", byte(result.Type))
+	}
+	return result
+}
+
+func (r *reader) functionDefinition() FunctionDefinition {
+	return FunctionDefinition{
+		Function: r.index(),
+		Flags:    r.u8(),
+		Code:     r.codeUnit(),
+	}
+}
+
+// codeUnit reads the max stack size, the locals signature index and the instructions,
+// which are prefixed with their count as uint16.
+func (r *reader) codeUnit() CodeUnit {
+	result := CodeUnit{
+		MaxStackSize: r.index(),
+		Locals:       r.index(),
+	}
+	n := int(r.u16())
+	for i := 0; i < n && r.err == nil; i++ {
+		result.Code = append(result.Code, r.instruction())
+	}
+	return result
+}
+
+func (r *reader) instruction() Instruction {
+	start := r.pos
+	result := Instruction{Opcode: Opcode(r.u8())}
+	if r.err != nil {
+		return Instruction{}
+	}
+	info, ok := opcodeInfos[result.Opcode]
+	if !ok {
+		r.failAt(start, types.BinaryError_UnknownOpcode, "Unknown opcode 0x%x", byte(result.Opcode))
+		return Instruction{}
+	}
+	switch info.operand {
+	case noOperand:
+	case constOperand:
+		result.Operand = r.u64()
+	case codeOffsetOperand:
+		result.Operand = uint64(r.u16())
+	case localOperand:
+		result.Operand = uint64(r.u8())
+	default:
+		result.Operand = uint64(r.index())
+	}
+	return result
+}
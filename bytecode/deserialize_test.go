@@ -0,0 +1,293 @@
+package bytecode_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"github.com/philippgille/libra-sdk-go/bytecode"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// table is a table of a test binary.
+type table struct {
+	kind bytecode.TableType
+	data []byte
+}
+
+// newBinary serializes the tables into a binary with the header and table headers.
+func newBinary(tables ...table) []byte {
+	result := append([]byte{}, bytecode.Magic...)
+	result = append(result, bytecode.VersionMajor, bytecode.VersionMinor, byte(len(tables)))
+	offset := len(result) + 9*len(tables)
+	for _, t := range tables {
+		result = append(result, byte(t.kind))
+		result = append(result, u32(uint32(offset))...)
+		result = append(result, u32(uint32(len(t.data)))...)
+		offset += len(t.data)
+	}
+	for _, t := range tables {
+		result = append(result, t.data...)
+	}
+	return result
+}
+
+func u16(v uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return b
+}
+
+func u32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	return b
+}
+
+func uleb(v uint64) []byte {
+	var result []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(result, b)
+		}
+		result = append(result, b|0x80)
+	}
+}
+
+// concat concatenates byte slices and single bytes.
+func concat(parts ...interface{}) []byte {
+	var result []byte
+	for _, part := range parts {
+		switch p := part.(type) {
+		case byte:
+			result = append(result, p)
+		case bytecode.Opcode:
+			result = append(result, byte(p))
+		case bytecode.SerializedType:
+			result = append(result, byte(p))
+		case int:
+			result = append(result, uleb(uint64(p))...)
+		case []byte:
+			result = append(result, p...)
+		case string:
+			result = append(result, uleb(uint64(len(p)))...)
+			result = append(result, p...)
+		}
+	}
+	return result
+}
+
+// newTransferScript returns a script like Libra's peer-to-peer transfer script.
+func newTransferScript() []byte {
+	return newBinary(
+		// <SELF> and LibraAccount
+		table{bytecode.ModuleHandlesTable, concat(0, 0, 0, 1)},
+		// main and LibraAccount.pay_from_sender
+		table{bytecode.FunctionHandlesTable, concat(0, 2, 0, 1, 3, 0)},
+		table{bytecode.AddressPoolTable, make([]byte, bytecode.AddressLength)},
+		table{bytecode.StringPoolTable, concat("<SELF>", "LibraAccount", "main", "pay_from_sender")},
+		table{bytecode.MainTable, concat(0, bytecode.PublicFunction, 2, 0, u16(4),
+			bytecode.MoveLoc, byte(0), bytecode.MoveLoc, byte(1), bytecode.Call, 1, bytecode.Ret)},
+		table{bytecode.FunctionSignaturesTable, concat(byte(2), byte(0), byte(2), bytecode.Address, bytecode.U64)},
+		table{bytecode.LocalsSignaturesTable, concat(byte(3), byte(2), bytecode.Address, bytecode.U64)},
+	)
+}
+
+// newCoinModule returns a module with a resource, a field, a native function and instructions with all kinds of operands.
+func newCoinModule() []byte {
+	return newBinary(
+		table{bytecode.ModuleHandlesTable, concat(0, 0)},
+		table{bytecode.StructHandlesTable, concat(0, 1, byte(1))},
+		// get, check and hash
+		table{bytecode.FunctionHandlesTable, concat(0, 3, 0, 0, 5, 1, 0, 6, 1)},
+		table{bytecode.AddressPoolTable, append(make([]byte, bytecode.AddressLength-1), 1)},
+		table{bytecode.StringPoolTable, concat("Coin", "T", "value", "get", "hello", "check", "hash")},
+		table{bytecode.ByteArrayPoolTable, concat([]byte{2}, byte(0xca), byte(0xfe))},
+		table{bytecode.StructDefsTable, concat(0, 1, 0)},
+		table{bytecode.FieldDefsTable, concat(0, 2, 0)},
+		table{bytecode.FunctionDefsTable, concat(
+			0, bytecode.PublicFunction, 1, 0, u16(4),
+			bytecode.MoveLoc, byte(0), bytecode.BorrowField, 0, bytecode.ReadRef, bytecode.Ret,
+			1, byte(0), 1, 1, u16(11),
+			bytecode.LdConst, []byte{42, 0, 0, 0, 0, 0, 0, 0}, bytecode.Pop,
+			bytecode.LdStr, 4, bytecode.Pop,
+			bytecode.LdByteArray, 0, bytecode.Pop,
+			bytecode.LdAddr, 0, bytecode.Pop,
+			bytecode.LdTrue, bytecode.BrTrue, u16(0), bytecode.Ret,
+			2, bytecode.PublicFunction|bytecode.NativeFunction, 0, 1, u16(0),
+		)},
+		table{bytecode.TypeSignaturesTable, concat(byte(1), bytecode.U64)},
+		table{bytecode.FunctionSignaturesTable, concat(
+			byte(2), byte(1), bytecode.U64, byte(1), bytecode.Reference, bytecode.Struct, 0,
+			byte(2), byte(0), byte(0),
+		)},
+		table{bytecode.LocalsSignaturesTable, concat(
+			byte(3), byte(1), bytecode.Reference, bytecode.Struct, 0,
+			byte(3), byte(0),
+		)},
+	)
+}
+
+// TestDeserializeScript tests if the tables and the main function of a script are deserialized.
+func TestDeserializeScript(t *testing.T) {
+	script, err := bytecode.DeserializeScript(newTransferScript())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := bytecode.Script{
+		Tables: bytecode.Tables{
+			ModuleHandles:      []bytecode.ModuleHandle{{Address: 0, Name: 0}, {Address: 0, Name: 1}},
+			FunctionHandles:    []bytecode.FunctionHandle{{Module: 0, Name: 2}, {Module: 1, Name: 3}},
+			FunctionSignatures: []bytecode.FunctionSignature{{ArgTypes: []bytecode.SignatureToken{{Type: bytecode.Address}, {Type: bytecode.U64}}}},
+			LocalsSignatures:   [][]bytecode.SignatureToken{{{Type: bytecode.Address}, {Type: bytecode.U64}}},
+			StringPool:         []string{"<SELF>", "LibraAccount", "main", "pay_from_sender"},
+			AddressPool:        [][]byte{make([]byte, bytecode.AddressLength)},
+		},
+		Main: bytecode.FunctionDefinition{
+			Function: 0,
+			Flags:    bytecode.PublicFunction,
+			Code: bytecode.CodeUnit{
+				MaxStackSize: 2,
+				Code: []bytecode.Instruction{
+					{Opcode: bytecode.MoveLoc, Operand: 0},
+					{Opcode: bytecode.MoveLoc, Operand: 1},
+					{Opcode: bytecode.Call, Operand: 1},
+					{Opcode: bytecode.Ret},
+				},
+			},
+		},
+	}
+	if diff := deep.Equal(script, expected); diff != nil {
+		t.Fatal(diff)
+	}
+
+	isScript, err := bytecode.IsScript(newTransferScript())
+	if err != nil || !isScript {
+		t.Fatalf("Expected a script, but was %v (error: %v)", isScript, err)
+	}
+}
+
+// TestDeserializeModule tests if the definitions and the instruction operands of a module are deserialized.
+func TestDeserializeModule(t *testing.T) {
+	module, err := bytecode.DeserializeModule(newCoinModule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(module.StructHandles, []bytecode.StructHandle{{Module: 0, Name: 1, IsResource: true}}); diff != nil {
+		t.Fatal(diff)
+	}
+	if diff := deep.Equal(module.StructDefs, []bytecode.StructDefinition{{StructHandle: 0, FieldCount: 1, Fields: 0}}); diff != nil {
+		t.Fatal(diff)
+	}
+	if diff := deep.Equal(module.FieldDefs, []bytecode.FieldDefinition{{Struct: 0, Name: 2, Signature: 0}}); diff != nil {
+		t.Fatal(diff)
+	}
+	expectedSig := bytecode.FunctionSignature{
+		ReturnTypes: []bytecode.SignatureToken{{Type: bytecode.U64}},
+		ArgTypes: []bytecode.SignatureToken{{
+			Type:       bytecode.Reference,
+			Referenced: &bytecode.SignatureToken{Type: bytecode.Struct, StructHandle: 0},
+		}},
+	}
+	if diff := deep.Equal(module.FunctionSignatures[0], expectedSig); diff != nil {
+		t.Fatal(diff)
+	}
+	if !bytes.Equal(module.ByteArrayPool[0], []byte{0xca, 0xfe}) {
+		t.Fatalf("Unexpected byte array pool: %x", module.ByteArrayPool)
+	}
+
+	if len(module.FunctionDefs) != 3 {
+		t.Fatalf("Expected 3 function definitions, but was %v", len(module.FunctionDefs))
+	}
+	check := module.FunctionDefs[1]
+	if check.IsPublic() || check.IsNative() {
+		t.Fatal("Expected check() to be private and not native")
+	}
+	expectedCode := []bytecode.Instruction{
+		{Opcode: bytecode.LdConst, Operand: 42}, {Opcode: bytecode.Pop},
+		{Opcode: bytecode.LdStr, Operand: 4}, {Opcode: bytecode.Pop},
+		{Opcode: bytecode.LdByteArray, Operand: 0}, {Opcode: bytecode.Pop},
+		{Opcode: bytecode.LdAddr, Operand: 0}, {Opcode: bytecode.Pop},
+		{Opcode: bytecode.LdTrue}, {Opcode: bytecode.BrTrue, Operand: 0}, {Opcode: bytecode.Ret},
+	}
+	if diff := deep.Equal(check.Code.Code, expectedCode); diff != nil {
+		t.Fatal(diff)
+	}
+	if hash := module.FunctionDefs[2]; !hash.IsPublic() || !hash.IsNative() {
+		t.Fatal("Expected hash() to be public and native")
+	}
+
+	isScript, err := bytecode.IsScript(newCoinModule())
+	if err != nil || isScript {
+		t.Fatalf("Expected a module, but was script: %v (error: %v)", isScript, err)
+	}
+}
+
+// TestDeserializeErrors tests if invalid binaries lead to errors with the BinaryError of the Libra VM.
+func TestDeserializeErrors(t *testing.T) {
+	valid := newBinary(table{bytecode.StringPoolTable, concat("Coin")})
+	badOffset := append([]byte{}, valid...)
+	// The offset of the first table header follows its table type
+	badOffset[len(bytecode.Magic)+4]++
+	badVersion := append([]byte{}, valid...)
+	badVersion[len(bytecode.Magic)] = 2
+
+	testCases := []struct {
+		name     string
+		binary   []byte
+		script   bool
+		expected types.BinaryError
+	}{
+		{"empty", nil, false, types.BinaryError_Malformed},
+		{"bad magic", append([]byte("LIBRAVM\r"), valid[len(bytecode.Magic):]...), false, types.BinaryError_BadMagic},
+		{"unknown version", badVersion, false, types.BinaryError_UnknownVersion},
+		{"truncated header", valid[:len(bytecode.Magic)+2], false, types.BinaryError_Malformed},
+		{"truncated table header", valid[:len(bytecode.Magic)+6], false, types.BinaryError_Malformed},
+		{"unknown table type", newBinary(table{bytecode.TableType(0x20), []byte{0}}), false, types.BinaryError_UnknownTableType},
+		{"duplicate table", newBinary(
+			table{bytecode.StringPoolTable, concat("a")},
+			table{bytecode.StringPoolTable, concat("b")},
+		), false, types.BinaryError_DuplicateTable},
+		{"bad table offset", badOffset, false, types.BinaryError_BadHeaderTable},
+		{"empty table", newBinary(table{bytecode.StringPoolTable, nil}), false, types.BinaryError_BadHeaderTable},
+		{"table exceeds binary", valid[:len(valid)-1], false, types.BinaryError_BadHeaderTable},
+		{"trailing bytes", append(append([]byte{}, valid...), 0), false, types.BinaryError_Malformed},
+		{"truncated string", newBinary(table{bytecode.StringPoolTable, concat(4, byte('a'))}), false, types.BinaryError_Malformed},
+		{"invalid UTF-8", newBinary(table{bytecode.StringPoolTable, concat("\xff")}), false, types.BinaryError_Malformed},
+		{"index overflow", newBinary(table{bytecode.ModuleHandlesTable, concat(1<<16, 0)}), false, types.BinaryError_Malformed},
+		{"ULEB128 overflow", newBinary(table{bytecode.ModuleHandlesTable, bytes.Repeat([]byte{0xff}, 11)}), false, types.BinaryError_Malformed},
+		{"address pool length", newBinary(table{bytecode.AddressPoolTable, make([]byte, 31)}), false, types.BinaryError_Malformed},
+		{"unknown signature type", newBinary(table{bytecode.TypeSignaturesTable, concat(byte(9), bytecode.U64)}), false, types.BinaryError_UnknownSignatureType},
+		{"unexpected signature type", newBinary(table{bytecode.TypeSignaturesTable, concat(byte(3), bytecode.U64)}), false, types.BinaryError_UnexpectedSignatureType},
+		{"unknown serialized type", newBinary(table{bytecode.TypeSignaturesTable, concat(byte(1), byte(0x20))}), false, types.BinaryError_UnknownSerializedType},
+		{"unknown opcode", newBinary(table{bytecode.MainTable, concat(0, byte(0), 0, 0, u16(1), byte(0xff))}), true, types.BinaryError_UnknownOpcode},
+		{"truncated code", newBinary(table{bytecode.MainTable, concat(0, byte(0), 0, 0, u16(2), bytecode.Ret)}), true, types.BinaryError_Malformed},
+		{"script without main", valid, true, types.BinaryError_Malformed},
+		{"script with definitions", newBinary(
+			table{bytecode.MainTable, concat(0, byte(0), 0, 0, u16(1), bytecode.Ret)},
+			table{bytecode.StructDefsTable, concat(0, 0, 0)},
+		), true, types.BinaryError_Malformed},
+		{"module with main", newBinary(table{bytecode.MainTable, concat(0, byte(0), 0, 0, u16(1), bytecode.Ret)}), false, types.BinaryError_Malformed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			if tc.script {
+				_, err = bytecode.DeserializeScript(tc.binary)
+			} else {
+				_, err = bytecode.DeserializeModule(tc.binary)
+			}
+			binaryErr, ok := err.(bytecode.Error)
+			if !ok {
+				t.Fatalf("Expected a bytecode.Error, but was %v", err)
+			}
+			if binaryErr.Kind != tc.expected {
+				t.Fatalf("Expected %v, but was %v", tc.expected, err)
+			}
+		})
+	}
+}
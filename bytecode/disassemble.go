@@ -0,0 +1,259 @@
+package bytecode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Disassemble deserializes a compiled script or module and returns its disassembly.
+// Binaries with a main function are deserialized as script, others as module.
+func Disassemble(binary []byte) (string, error) {
+	isScript, err := IsScript(binary)
+	if err != nil {
+		return "", err
+	}
+	if isScript {
+		script, err := DeserializeScript(binary)
+		if err != nil {
+			return "", err
+		}
+		return script.String(), nil
+	}
+	module, err := DeserializeModule(binary)
+	if err != nil {
+		return "", err
+	}
+	return module.String(), nil
+}
+
+// String returns the disassembly of the script, with the imported modules and the code of the main function:
+//
+//	script
+//	import 0x0000000000000000000000000000000000000000000000000000000000000000.LibraAccount
+//
+//	main(address, u64)
+//	  locals: address, u64
+//	  max stack size: 2
+//	  0: MoveLoc 0
+//	  1: MoveLoc 1
+//	  2: Call LibraAccount.pay_from_sender(address, u64)
+//	  3: Ret
+func (s Script) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString("script\n")
+	s.writeImports(sb)
+	sb.WriteString("\n")
+	s.writeFunction(sb, s.Main, nil)
+	return sb.String()
+}
+
+// String returns the disassembly of the module, with the imported modules, structs and functions.
+// The instructions refer to structs, fields and functions by name, and to constants by their value.
+func (m Module) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "module %v\n", m.moduleName(0))
+	m.writeImports(sb)
+	for _, def := range m.StructDefs {
+		sb.WriteString("\n")
+		if int(def.StructHandle) >= len(m.StructHandles) {
+			fmt.Fprintf(sb, "<invalid struct %v>\n", def.StructHandle)
+			continue
+		}
+		handle := m.StructHandles[def.StructHandle]
+		kind := "struct"
+		if handle.IsResource {
+			kind = "resource"
+		}
+		fmt.Fprintf(sb, "%v %v\n", kind, m.str(handle.Name))
+		for i := 0; i < int(def.FieldCount); i++ {
+			index := int(def.Fields) + i
+			if index >= len(m.FieldDefs) {
+				fmt.Fprintf(sb, "  <invalid field %v>\n", index)
+				continue
+			}
+			field := m.FieldDefs[index]
+			fmt.Fprintf(sb, "  %v: %v\n", m.str(field.Name), m.typeSignature(field.Signature))
+		}
+	}
+	for _, def := range m.FunctionDefs {
+		sb.WriteString("\n")
+		m.writeFunction(sb, def, &m)
+	}
+	return sb.String()
+}
+
+// TypeString formats a signature token, like "u64", "&mut LibraAccount.T" or "bytearray".
+func (t Tables) TypeString(token SignatureToken) string {
+	switch token.Type {
+	case Bool:
+		return "bool"
+	case U64:
+		return "u64"
+	case String:
+		return "string"
+	case Address:
+		return "address"
+	case ByteArray:
+		return "bytearray"
+	case Reference, MutableReference:
+		prefix := "&"
+		if token.Type == MutableReference {
+			prefix = "&mut "
+		}
+		if token.Referenced == nil {
+			return prefix + "<invalid>"
+		}
+		return prefix + t.TypeString(*token.Referenced)
+	case Struct:
+		return t.structName(token.StructHandle)
+	}
+	return fmt.Sprintf("<unknown type 0x%x>", byte(token.Type))
+}
+
+// writeImports writes the module handles except the first one, which is the module itself or a placeholder for scripts.
+func (t Tables) writeImports(sb *strings.Builder) {
+	for i := 1; i < len(t.ModuleHandles); i++ {
+		fmt.Fprintf(sb, "import %v.%v\n", t.address(t.ModuleHandles[i].Address), t.str(t.ModuleHandles[i].Name))
+	}
+}
+
+// writeFunction writes the signature, locals and code of a function.
+// The module is needed for instructions that refer to struct and field definitions and nil for scripts.
+func (t Tables) writeFunction(sb *strings.Builder, def FunctionDefinition, m *Module) {
+	if def.IsNative() {
+		sb.WriteString("native ")
+	}
+	// The main function of scripts is always public
+	if def.IsPublic() && m != nil {
+		sb.WriteString("public ")
+	}
+	_, name, signature := t.function(def.Function)
+	sb.WriteString(name + signature + "\n")
+	if def.IsNative() {
+		return
+	}
+	sb.WriteString("  locals:")
+	if int(def.Code.Locals) < len(t.LocalsSignatures) {
+		if locals := t.LocalsSignatures[def.Code.Locals]; len(locals) > 0 {
+			sb.WriteString(" " + t.typeList(locals))
+		}
+	} else {
+		fmt.Fprintf(sb, " <invalid locals signature %v>", def.Code.Locals)
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(sb, "  max stack size: %v\n", def.Code.MaxStackSize)
+	for i, ins := range def.Code.Code {
+		fmt.Fprintf(sb, "  %v: %v\n", i, t.instruction(ins, m))
+	}
+}
+
+// instruction formats an instruction with its operand resolved, e.g. the name of the called function.
+func (t Tables) instruction(ins Instruction, m *Module) string {
+	info := opcodeInfos[ins.Opcode]
+	var operand string
+	switch info.operand {
+	case noOperand:
+		return ins.Opcode.String()
+	case constOperand, codeOffsetOperand, localOperand:
+		operand = strconv.FormatUint(ins.Operand, 10)
+	case addressOperand:
+		operand = t.address(uint16(ins.Operand))
+	case stringOperand:
+		operand = strconv.Quote(t.str(uint16(ins.Operand)))
+	case byteArrayOperand:
+		if ins.Operand < uint64(len(t.ByteArrayPool)) {
+			operand = fmt.Sprintf("0x%x", t.ByteArrayPool[ins.Operand])
+		} else {
+			operand = fmt.Sprintf("<invalid byte array %v>", ins.Operand)
+		}
+	case functionHandleOperand:
+		module, name, signature := t.function(uint16(ins.Operand))
+		operand = module + "." + name + signature
+	case structDefOperand:
+		if m != nil && ins.Operand < uint64(len(m.StructDefs)) {
+			operand = t.structName(m.StructDefs[ins.Operand].StructHandle)
+		} else {
+			operand = fmt.Sprintf("<invalid struct definition %v>", ins.Operand)
+		}
+	case fieldDefOperand:
+		if m != nil && ins.Operand < uint64(len(m.FieldDefs)) {
+			field := m.FieldDefs[ins.Operand]
+			operand = t.structName(field.Struct) + "." + t.str(field.Name)
+		} else {
+			operand = fmt.Sprintf("<invalid field definition %v>", ins.Operand)
+		}
+	}
+	return ins.Opcode.String() + " " + operand
+}
+
+// functionSignature formats a function signature like "(address, u64): bool".
+func (t Tables) functionSignature(index uint16) string {
+	if int(index) >= len(t.FunctionSignatures) {
+		return fmt.Sprintf("(<invalid function signature %v>)", index)
+	}
+	sig := t.FunctionSignatures[index]
+	result := "(" + t.typeList(sig.ArgTypes) + ")"
+	switch len(sig.ReturnTypes) {
+	case 0:
+		return result
+	case 1:
+		return result + ": " + t.TypeString(sig.ReturnTypes[0])
+	default:
+		return result + ": (" + t.typeList(sig.ReturnTypes) + ")"
+	}
+}
+
+func (t Tables) typeList(tokens []SignatureToken) string {
+	var result []string
+	for _, token := range tokens {
+		result = append(result, t.TypeString(token))
+	}
+	return strings.Join(result, ", ")
+}
+
+func (t Tables) typeSignature(index uint16) string {
+	if int(index) >= len(t.TypeSignatures) {
+		return fmt.Sprintf("<invalid type signature %v>", index)
+	}
+	return t.TypeString(t.TypeSignatures[index])
+}
+
+// structName formats the struct of a struct handle like "LibraAccount.T".
+func (t Tables) structName(index uint16) string {
+	if int(index) >= len(t.StructHandles) {
+		return fmt.Sprintf("<invalid struct %v>", index)
+	}
+	handle := t.StructHandles[index]
+	return t.moduleName(handle.Module) + "." + t.str(handle.Name)
+}
+
+// function returns the module name, name and formatted signature of a function handle.
+func (t Tables) function(index uint16) (module, name, signature string) {
+	if int(index) >= len(t.FunctionHandles) {
+		return "<invalid module>", fmt.Sprintf("<invalid function %v>", index), "()"
+	}
+	handle := t.FunctionHandles[index]
+	return t.moduleName(handle.Module), t.str(handle.Name), t.functionSignature(handle.Signature)
+}
+
+func (t Tables) moduleName(index uint16) string {
+	if int(index) >= len(t.ModuleHandles) {
+		return fmt.Sprintf("<invalid module %v>", index)
+	}
+	return t.str(t.ModuleHandles[index].Name)
+}
+
+func (t Tables) str(index uint16) string {
+	if int(index) >= len(t.StringPool) {
+		return fmt.Sprintf("<invalid string %v>", index)
+	}
+	return t.StringPool[index]
+}
+
+func (t Tables) address(index uint16) string {
+	if int(index) >= len(t.AddressPool) {
+		return fmt.Sprintf("<invalid address %v>", index)
+	}
+	return fmt.Sprintf("0x%x", t.AddressPool[index])
+}
@@ -0,0 +1,89 @@
+package bytecode_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/philippgille/libra-sdk-go/bytecode"
+)
+
+// TestDisassembleScript tests the disassembly of a script.
+func TestDisassembleScript(t *testing.T) {
+	expected := `script
+import 0x0000000000000000000000000000000000000000000000000000000000000000.LibraAccount
+
+main(address, u64)
+  locals: address, u64
+  max stack size: 2
+  0: MoveLoc 0
+  1: MoveLoc 1
+  2: Call LibraAccount.pay_from_sender(address, u64)
+  3: Ret
+`
+	disassembly, err := bytecode.Disassemble(newTransferScript())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disassembly != expected {
+		t.Fatalf("Expected:\n%v\nbut was:\n%v", expected, disassembly)
+	}
+}
+
+// TestDisassembleModule tests the disassembly of a module, with resolved operands.
+func TestDisassembleModule(t *testing.T) {
+	expected := `module Coin
+
+resource T
+  value: u64
+
+public get(&Coin.T): u64
+  locals: &Coin.T
+  max stack size: 1
+  0: MoveLoc 0
+  1: BorrowField Coin.T.value
+  2: ReadRef
+  3: Ret
+
+check()
+  locals:
+  max stack size: 1
+  0: LdConst 42
+  1: Pop
+  2: LdStr "hello"
+  3: Pop
+  4: LdByteArray 0xcafe
+  5: Pop
+  6: LdAddr 0x0000000000000000000000000000000000000000000000000000000000000001
+  7: Pop
+  8: LdTrue
+  9: BrTrue 0
+  10: Ret
+
+native public hash()
+`
+	disassembly, err := bytecode.Disassemble(newCoinModule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disassembly != expected {
+		t.Fatalf("Expected:\n%v\nbut was:\n%v", expected, disassembly)
+	}
+}
+
+// TestDisassembleInvalidIndexes tests if the disassembly of binaries with out-of-bounds indexes doesn't panic.
+// Such binaries are rejected by Libra's bounds checker, not by the deserializer.
+func TestDisassembleInvalidIndexes(t *testing.T) {
+	binary := newBinary(
+		table{bytecode.MainTable, concat(5, bytecode.PublicFunction, 1, 3, u16(3),
+			bytecode.Call, 7, bytecode.LdStr, 8, bytecode.Pack, 9)},
+	)
+	disassembly, err := bytecode.Disassemble(binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"<invalid function 5>", "<invalid function 7>", "<invalid string 8>", "<invalid struct definition 9>", "<invalid locals signature 3>"} {
+		if !strings.Contains(disassembly, s) {
+			t.Errorf("Expected the disassembly to contain %q, but was:\n%v", s, disassembly)
+		}
+	}
+}
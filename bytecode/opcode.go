@@ -0,0 +1,154 @@
+package bytecode
+
+import (
+	"fmt"
+)
+
+// Opcode is the opcode of an instruction.
+type Opcode byte
+
+// Opcodes of the instructions
+const (
+	Pop                  Opcode = 0x01
+	Ret                  Opcode = 0x02
+	BrTrue               Opcode = 0x03
+	BrFalse              Opcode = 0x04
+	Branch               Opcode = 0x05
+	LdConst              Opcode = 0x06
+	LdAddr               Opcode = 0x07
+	LdStr                Opcode = 0x08
+	LdTrue               Opcode = 0x09
+	LdFalse              Opcode = 0x0A
+	CopyLoc              Opcode = 0x0B
+	MoveLoc              Opcode = 0x0C
+	StLoc                Opcode = 0x0D
+	BorrowLoc            Opcode = 0x0E
+	BorrowField          Opcode = 0x0F
+	LdByteArray          Opcode = 0x10
+	Call                 Opcode = 0x11
+	Pack                 Opcode = 0x12
+	Unpack               Opcode = 0x13
+	ReadRef              Opcode = 0x14
+	WriteRef             Opcode = 0x15
+	Add                  Opcode = 0x16
+	Sub                  Opcode = 0x17
+	Mul                  Opcode = 0x18
+	Mod                  Opcode = 0x19
+	Div                  Opcode = 0x1A
+	BitOr                Opcode = 0x1B
+	BitAnd               Opcode = 0x1C
+	Xor                  Opcode = 0x1D
+	Or                   Opcode = 0x1E
+	And                  Opcode = 0x1F
+	Not                  Opcode = 0x20
+	Eq                   Opcode = 0x21
+	Neq                  Opcode = 0x22
+	Lt                   Opcode = 0x23
+	Gt                   Opcode = 0x24
+	Le                   Opcode = 0x25
+	Ge                   Opcode = 0x26
+	Assert               Opcode = 0x27
+	GetTxnGasUnitPrice   Opcode = 0x28
+	GetTxnMaxGasUnits    Opcode = 0x29
+	GetGasRemaining      Opcode = 0x2A
+	GetTxnSenderAddress  Opcode = 0x2B
+	Exists               Opcode = 0x2C
+	BorrowGlobal         Opcode = 0x2D
+	ReleaseRef           Opcode = 0x2E
+	MoveFrom             Opcode = 0x2F
+	MoveToSender         Opcode = 0x30
+	CreateAccount        Opcode = 0x31
+	EmitEvent            Opcode = 0x32
+	GetTxnSequenceNumber Opcode = 0x33
+	GetTxnPublicKey      Opcode = 0x34
+	FreezeRef            Opcode = 0x35
+)
+
+// operandKind is the kind of an instruction's operand, which determines its encoding and what it refers to.
+type operandKind int
+
+const (
+	noOperand operandKind = iota
+	// constOperand is a uint64 constant, fixed size little-endian
+	constOperand
+	// codeOffsetOperand is the index of an instruction, uint16 fixed size little-endian
+	codeOffsetOperand
+	// localOperand is the index of a local, single byte
+	localOperand
+	// The other operands are ULEB128 encoded indexes into a table
+	addressOperand
+	stringOperand
+	byteArrayOperand
+	fieldDefOperand
+	functionHandleOperand
+	structDefOperand
+)
+
+type opcodeInfo struct {
+	name    string
+	operand operandKind
+}
+
+var opcodeInfos = map[Opcode]opcodeInfo{
+	Pop:                  {"Pop", noOperand},
+	Ret:                  {"Ret", noOperand},
+	BrTrue:               {"BrTrue", codeOffsetOperand},
+	BrFalse:              {"BrFalse", codeOffsetOperand},
+	Branch:               {"Branch", codeOffsetOperand},
+	LdConst:              {"LdConst", constOperand},
+	LdAddr:               {"LdAddr", addressOperand},
+	LdStr:                {"LdStr", stringOperand},
+	LdTrue:               {"LdTrue", noOperand},
+	LdFalse:              {"LdFalse", noOperand},
+	CopyLoc:              {"CopyLoc", localOperand},
+	MoveLoc:              {"MoveLoc", localOperand},
+	StLoc:                {"StLoc", localOperand},
+	BorrowLoc:            {"BorrowLoc", localOperand},
+	BorrowField:          {"BorrowField", fieldDefOperand},
+	LdByteArray:          {"LdByteArray", byteArrayOperand},
+	Call:                 {"Call", functionHandleOperand},
+	Pack:                 {"Pack", structDefOperand},
+	Unpack:               {"Unpack", structDefOperand},
+	ReadRef:              {"ReadRef", noOperand},
+	WriteRef:             {"WriteRef", noOperand},
+	Add:                  {"Add", noOperand},
+	Sub:                  {"Sub", noOperand},
+	Mul:                  {"Mul", noOperand},
+	Mod:                  {"Mod", noOperand},
+	Div:                  {"Div", noOperand},
+	BitOr:                {"BitOr", noOperand},
+	BitAnd:               {"BitAnd", noOperand},
+	Xor:                  {"Xor", noOperand},
+	Or:                   {"Or", noOperand},
+	And:                  {"And", noOperand},
+	Not:                  {"Not", noOperand},
+	Eq:                   {"Eq", noOperand},
+	Neq:                  {"Neq", noOperand},
+	Lt:                   {"Lt", noOperand},
+	Gt:                   {"Gt", noOperand},
+	Le:                   {"Le", noOperand},
+	Ge:                   {"Ge", noOperand},
+	Assert:               {"Assert", noOperand},
+	GetTxnGasUnitPrice:   {"GetTxnGasUnitPrice", noOperand},
+	GetTxnMaxGasUnits:    {"GetTxnMaxGasUnits", noOperand},
+	GetGasRemaining:      {"GetGasRemaining", noOperand},
+	GetTxnSenderAddress:  {"GetTxnSenderAddress", noOperand},
+	Exists:               {"Exists", structDefOperand},
+	BorrowGlobal:         {"BorrowGlobal", structDefOperand},
+	ReleaseRef:           {"ReleaseRef", noOperand},
+	MoveFrom:             {"MoveFrom", structDefOperand},
+	MoveToSender:         {"MoveToSender", structDefOperand},
+	CreateAccount:        {"CreateAccount", noOperand},
+	EmitEvent:            {"EmitEvent", noOperand},
+	GetTxnSequenceNumber: {"GetTxnSequenceNumber", noOperand},
+	GetTxnPublicKey:      {"GetTxnPublicKey", noOperand},
+	FreezeRef:            {"FreezeRef", noOperand},
+}
+
+// String returns the name of the opcode, like "MoveLoc".
+func (op Opcode) String() string {
+	if info, ok := opcodeInfos[op]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("unknown opcode 0x%x", byte(op))
+}
@@ -3,6 +3,8 @@ package libra
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"google.golang.org/grpc"
@@ -16,14 +18,34 @@ import (
 type Client struct {
 	// Only for printing. It was only used to create the gRPC client connection.
 	address string
-	// Shouldn't need to be used. It was only used to create the AdmissionControlClient.
-	conn *grpc.ClientConn
+	// Shouldn't need to be used. They were only used to create the AdmissionControlClient.
+	conns []*grpc.ClientConn
 	// Actual client
 	acc admission_control.AdmissionControlClient
+	// Only set for multi-node clients. It's acc or wrapped by acc.
+	pool *nodePool
+	// Expiration times of sent transactions, used by WaitForTransaction()
+	sent *sentTxs
+	// Optional, allocates the sequence numbers of the transaction helpers, see WithSequenceManager()
+	seqNos *SequenceManager
+	// Optional, the fee policy and fixed fee of the transaction helpers, see WithFeePolicy() and WithFee()
+	feePolicy *FeePolicy
+	fee       *Fee
+	// Optional, the TTL of the transaction helpers' transactions, see WithTransactionTTL(). If 0, DefaultTransactionTTL is used.
+	txTTL time.Duration
 }
 
+// ErrAccountNotFound is returned when a requested account doesn't exist on the ledger.
+var ErrAccountNotFound = errors.New("The account doesn't exist")
+
 // GetAccountState requests the state of the given account.
+// If the account doesn't exist, ErrAccountNotFound is returned.
 func (c Client) GetAccountState(accountAddr string) (AccountState, error) {
+	return c.GetAccountStateContext(context.Background(), accountAddr)
+}
+
+// GetAccountStateContext is like GetAccountState(...), but the request is bound to the given context.
+func (c Client) GetAccountStateContext(ctx context.Context, accountAddr string) (AccountState, error) {
 	accountAddrBytes, err := hex.DecodeString(accountAddr)
 	if err != nil {
 		return AccountState{}, err
@@ -49,37 +71,87 @@ func (c Client) GetAccountState(accountAddr string) (AccountState, error) {
 		ClientKnownVersion: knownVersion,
 		RequestedItems:     requestedItems,
 	}
-	updateLedgerResponse, err := c.acc.UpdateToLatestLedger(context.Background(), &updateLedgerRequest)
+	updateLedgerResponse, err := c.acc.UpdateToLatestLedger(ctx, &updateLedgerRequest)
 	if err != nil {
 		return AccountState{}, err
 	}
 
 	// We only put one request item in the request, so there should only be one response.
 	accStateBlob := updateLedgerResponse.GetResponseItems()[0].GetGetAccountStateResponse().GetAccountStateWithProof().GetBlob().GetBlob()
+	if len(accStateBlob) == 0 {
+		return AccountState{}, ErrAccountNotFound
+	}
 
 	return FromAccountStateBlob(accStateBlob)
 }
 
 // SendTx sends a transaction to the connected validator node.
+// If the validator node doesn't accept the transaction, a SubmitError is returned.
 func (c Client) SendTx(tx Transaction) error {
+	return c.SendTxContext(context.Background(), tx)
+}
+
+// SendTxContext is like SendTx(...), but the request is bound to the given context.
+func (c Client) SendTxContext(ctx context.Context, tx Transaction) error {
 	txRequest := admission_control.SubmitTransactionRequest{
-		SignedTxn: &types.SignedTransaction{
-			RawTxnBytes:     tx.RawBytes,
-			SenderPublicKey: tx.SenderPubKey,
-			SenderSignature: tx.SenderSig,
-		},
+		SignedTxn: tx.toProto(),
 	}
-	_, err := c.acc.SubmitTransaction(context.Background(), &txRequest)
-	return err
+	txResponse, err := c.acc.SubmitTransaction(ctx, &txRequest)
+	if err != nil {
+		return err
+	}
+	err = submitResponseToError(txResponse)
+	if err != nil {
+		return err
+	}
+	c.sent.add(tx)
+	return nil
 }
 
-// Close closes the underlying gRPC connection.
+// requestItem requests a single item via UpdateToLatestLedger
+// and returns the corresponding response item as well as the ledger info of the response.
+func (c Client) requestItem(ctx context.Context, requestItem *types.RequestItem) (*types.ResponseItem, *types.LedgerInfo, error) {
+	updateLedgerResponse, err := c.updateToLatestLedger(ctx, requestItem)
+	if err != nil {
+		return nil, nil, err
+	}
+	responseItems := updateLedgerResponse.GetResponseItems()
+	if len(responseItems) != 1 {
+		return nil, nil, fmt.Errorf("Expected 1 response item, but got %v", len(responseItems))
+	}
+	return responseItems[0], updateLedgerResponse.GetLedgerInfoWithSigs().GetLedgerInfo(), nil
+}
+
+// latestLedgerInfo requests the latest ledger info without any request items.
+func (c Client) latestLedgerInfo(ctx context.Context) (*types.LedgerInfo, error) {
+	updateLedgerResponse, err := c.updateToLatestLedger(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return updateLedgerResponse.GetLedgerInfoWithSigs().GetLedgerInfo(), nil
+}
+
+// updateToLatestLedger sends an UpdateToLatestLedger request with the given request items.
+func (c Client) updateToLatestLedger(ctx context.Context, requestItems ...*types.RequestItem) (*types.UpdateToLatestLedgerResponse, error) {
+	updateLedgerRequest := types.UpdateToLatestLedgerRequest{
+		RequestedItems: requestItems,
+	}
+	return c.acc.UpdateToLatestLedger(ctx, &updateLedgerRequest)
+}
+
+// Close closes the underlying gRPC connections.
 func (c Client) Close() {
-	c.conn.Close()
+	if c.pool != nil {
+		c.pool.close()
+	}
+	for _, conn := range c.conns {
+		conn.Close()
+	}
 }
 
 // NewClient creates a new Libra client.
 // It connects to the given validator node via gRPC.
+// Failed requests are retried according to DefaultRetryPolicy, see Client.WithRetryPolicy(...).
 // The connection is kept open until Close() is called on the client.
 func NewClient(address string, dialTimeout time.Duration) (Client, error) {
 	ctxWithTimeout, cancelFunc := context.WithTimeout(context.Background(), dialTimeout)
@@ -91,9 +163,11 @@ func NewClient(address string, dialTimeout time.Duration) (Client, error) {
 		return Client{}, err
 	}
 	acc := admission_control.NewAdmissionControlClient(conn)
-	return Client{
+	c := Client{
 		address: address,
-		conn:    conn,
+		conns:   []*grpc.ClientConn{conn},
 		acc:     acc,
-	}, nil
+		sent:    newSentTxs(),
+	}
+	return c.WithRetryPolicy(DefaultRetryPolicy), nil
 }
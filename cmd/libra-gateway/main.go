@@ -0,0 +1,56 @@
+// Command libra-gateway serves a JSON/HTTP gateway for Libra's AdmissionControl gRPC service.
+// See the gateway package for the endpoints.
+//
+// Usage:
+//
+//	libra-gateway [flags]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/gateway"
+)
+
+var (
+	addr    = flag.String("addr", "ac.testnet.libra.org:8000", "Address of the validator node, or multiple comma-separated addresses for failover")
+	listen  = flag.String("listen", "localhost:8080", "Address the HTTP server listens on")
+	timeout = flag.Duration("timeout", gateway.DefaultTimeout, "Timeout for connecting to the validator node and for requests")
+)
+
+// healthCheckInterval is the interval of the health checks when multiple addresses are given.
+const healthCheckInterval = 10 * time.Second
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: libra-gateway [flags]\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var c libra.Client
+	var err error
+	if strings.Contains(*addr, ",") {
+		c, err = libra.NewMultiNodeClient(strings.Split(*addr, ","), *timeout, healthCheckInterval)
+	} else {
+		c, err = libra.NewClient(*addr, *timeout)
+	}
+	if err != nil {
+		log.Fatalf("Couldn't connect to %v: %v", *addr, err)
+	}
+	defer c.Close()
+
+	g := gateway.New(c)
+	g.Timeout = *timeout
+	log.Printf("Listening on %v\n", *listen)
+	if err := http.ListenAndServe(*listen, g); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+func runAccountState(args []string) error {
+	accState, err := getAccountState("account state", args)
+	if err != nil {
+		return err
+	}
+	accRes := accState.AccountResource
+	return printResult(accState, keyValueTable(
+		"Authentication key", hex.EncodeToString(accRes.AuthKey),
+		"Balance", accRes.Balance.String()+" LBR",
+		"Sequence number", strconv.FormatUint(accRes.SequenceNo, 10),
+		"Sent events", strconv.FormatUint(accRes.SentEvents, 10),
+		"Received events", strconv.FormatUint(accRes.ReceivedEvents, 10),
+	))
+}
+
+func runAccountBalance(args []string) error {
+	accState, err := getAccountState("account balance", args)
+	if err != nil {
+		return err
+	}
+	balance := accState.AccountResource.Balance
+	return printResult(struct {
+		Balance libra.Amount `json:"balance"`
+	}{balance}, keyValueTable("Balance", balance.String()+" LBR"))
+}
+
+func runAccountSequence(args []string) error {
+	accState, err := getAccountState("account sequence", args)
+	if err != nil {
+		return err
+	}
+	seqNo := accState.AccountResource.SequenceNo
+	return printResult(struct {
+		SequenceNo uint64 `json:"sequence_number,string"`
+	}{seqNo}, keyValueTable("Sequence number", strconv.FormatUint(seqNo, 10)))
+}
+
+// getAccountState parses the address argument of an account command and requests the account state.
+func getAccountState(name string, args []string) (libra.AccountState, error) {
+	fs := newFlagSet(name, "<address>")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return libra.AccountState{}, err
+	}
+	accAddr, err := libra.ParseAccountAddress(args[0])
+	if err != nil {
+		return libra.AccountState{}, err
+	}
+	c, err := connect()
+	if err != nil {
+		return libra.AccountState{}, err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	defer cancel()
+	return c.GetAccountStateContext(ctx, accAddr.String())
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/bytecode"
+)
+
+// disassembly is the JSON output of the disassemble command.
+type disassembly struct {
+	Script  string   `json:"script,omitempty"`
+	Modules []string `json:"modules,omitempty"`
+}
+
+func runDisassemble(args []string) error {
+	usage := "<file> | -tx <address> <sequence number>"
+	fs := newFlagSet("disassemble", usage)
+	fromTx := fs.Bool("tx", false, "Disassemble the script and modules of the account's transaction with the sequence number instead of a file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	n := 1
+	if *fromTx {
+		n = 2
+	}
+	if fs.NArg() != n {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	args = fs.Args()
+
+	var script []byte
+	var modules [][]byte
+	if *fromTx {
+		program, err := fetchProgram(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		script, modules = program.Code, program.Modules
+	} else {
+		binary, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		isScript, err := bytecode.IsScript(binary)
+		if err != nil {
+			return err
+		}
+		if isScript {
+			script = binary
+		} else {
+			modules = [][]byte{binary}
+		}
+	}
+
+	var result disassembly
+	var texts []string
+	for i, module := range modules {
+		m, err := bytecode.DeserializeModule(module)
+		if err != nil {
+			return fmt.Errorf("Module %v: %v", i, err)
+		}
+		result.Modules = append(result.Modules, m.String())
+		texts = append(texts, m.String())
+	}
+	if script != nil {
+		s, err := bytecode.DeserializeScript(script)
+		if err != nil {
+			return err
+		}
+		result.Script = s.String()
+		texts = append(texts, s.String())
+	}
+	return printSummary(result, strings.Join(texts, "\n"))
+}
+
+// fetchProgram fetches the transaction of the sender with the sequence number and returns its program.
+func fetchProgram(senderArg, seqNoArg string) (*libra.Program, error) {
+	sender, err := libra.ParseAccountAddress(senderArg)
+	if err != nil {
+		return nil, err
+	}
+	seqNo, err := strconv.ParseUint(seqNoArg, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	defer cancel()
+	tx, err := c.GetAccountTransaction(ctx, sender, seqNo, false)
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err := libra.RawTransactionFromBytes(tx.Transaction.RawBytes)
+	if err != nil {
+		return nil, err
+	}
+	if rawTx.Program == nil {
+		return nil, errors.New("The transaction doesn't have a program, it's a write set transaction")
+	}
+	return rawTx.Program, nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/hex"
+	"math"
+	"strconv"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+func runEventsSent(args []string) error {
+	return runEvents("events sent", args, accesspath.SentEvents)
+}
+
+func runEventsReceived(args []string) error {
+	return runEvents("events received", args, accesspath.ReceivedEvents)
+}
+
+func runEvents(name string, args []string, eventHandle func(accountAddr []byte) *types.AccessPath) error {
+	fs := newFlagSet(name, "<address>")
+	start := fs.Uint64("start", 0, "Sequence number of the first event")
+	limit := fs.Uint64("limit", 10, "Maximum number of events to show")
+	latest := fs.Bool("latest", false, "Show the latest events in descending order, ignoring -start")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	accAddr, err := libra.ParseAccountAddress(args[0])
+	if err != nil {
+		return err
+	}
+	ascending := true
+	if *latest {
+		// The maximum sequence number represents the latest event
+		*start = math.MaxUint64
+		ascending = false
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	defer cancel()
+	events, err := c.GetEvents(ctx, eventHandle(accAddr.Bytes()), *start, ascending, *limit)
+	if err != nil {
+		return err
+	}
+
+	t := table{
+		header: []string{"SEQUENCE NUMBER", "TRANSACTION VERSION", "DATA"},
+	}
+	for _, event := range events {
+		t.rows = append(t.rows, []string{
+			strconv.FormatUint(event.SequenceNo, 10),
+			strconv.FormatUint(event.TransactionVersion, 10),
+			hex.EncodeToString(event.Data),
+		})
+	}
+	return printResult(events, t)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/wallet"
+)
+
+// keyFlags are the flags of commands that need a signer.
+// The private key is either read from a key file or derived from a wallet,
+// or an external signer command is used.
+// For accounts whose authentication key was rotated, the account address must be given,
+// because it can't be derived from the key anymore.
+type keyFlags struct {
+	keyFile        *string
+	recoveryFile   *string
+	account        *uint64
+	signerCmd      *string
+	accountAddress *string
+}
+
+func addKeyFlags(fs *flag.FlagSet) keyFlags {
+	return keyFlags{
+		keyFile:        fs.String("key", "", "File with the hex encoded ed25519 private key, as written by \"libra keygen\""),
+		recoveryFile:   fs.String("wallet", "", "Wallet recovery file to derive the private key from, instead of -key"),
+		account:        fs.Uint64("account", 0, "Index of the wallet account, used with -wallet"),
+		signerCmd:      fs.String("signer", "", "External signer command with arguments, separated by spaces, instead of -key (see libra.CommandSigner)"),
+		accountAddress: fs.String("account-address", "", "Address of the account the key signs for, required after the account's authentication key was rotated"),
+	}
+}
+
+// signer returns the signer that's specified by the flags.
+// With an account address, it's a libra.AccountSigner.
+func (kf keyFlags) signer() (libra.Signer, error) {
+	signer, err := kf.keySigner()
+	if err != nil || *kf.accountAddress == "" {
+		return signer, err
+	}
+	address, err := libra.ParseAccountAddress(*kf.accountAddress)
+	if err != nil {
+		return nil, err
+	}
+	return libra.AccountSigner{Signer: signer, Address: address}, nil
+}
+
+// keySigner returns the signer for the key that's specified by the flags.
+func (kf keyFlags) keySigner() (libra.Signer, error) {
+	count := 0
+	for _, f := range []string{*kf.keyFile, *kf.recoveryFile, *kf.signerCmd} {
+		if f != "" {
+			count++
+		}
+	}
+	switch {
+	case count > 1:
+		return nil, errors.New("Only one of -key, -wallet and -signer can be used")
+	case *kf.keyFile != "":
+		privateKey, err := readKeyFile(*kf.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return libra.PrivateKeySigner(privateKey), nil
+	case *kf.recoveryFile != "":
+		w, err := wallet.Recover(*kf.recoveryFile)
+		if err != nil {
+			return nil, err
+		}
+		return w.Account(*kf.account)
+	case *kf.signerCmd != "":
+		fields := strings.Fields(*kf.signerCmd)
+		if len(fields) == 0 {
+			return nil, errors.New("The -signer command is empty")
+		}
+		return libra.NewCommandSigner(fields[0], fields[1:]...)
+	default:
+		return nil, errors.New("One of -key, -wallet or -signer is required")
+	}
+}
+
+// readKeyFile reads a hex encoded ed25519 private key.
+// Both the 32 byte seed and the 64 byte private key are accepted.
+func readKeyFile(path string) (ed25519.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid key file: %v", err)
+	}
+	switch len(b) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(b), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(b), nil
+	default:
+		return nil, fmt.Errorf("Invalid key file: Invalid private key length: %v bytes", len(b))
+	}
+}
+
+// keyPair is the output of key and account related commands.
+type keyPair struct {
+	Index      *uint64              `json:"index,omitempty"`
+	Address    libra.AccountAddress `json:"address"`
+	PublicKey  string               `json:"public_key"`
+	PrivateKey string               `json:"private_key,omitempty"`
+}
+
+func newKeyPair(privateKey ed25519.PrivateKey, withPrivateKey bool) keyPair {
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	result := keyPair{
+		Address:   libra.AccountAddressFromPublicKey(publicKey),
+		PublicKey: hex.EncodeToString(publicKey),
+	}
+	if withPrivateKey {
+		result.PrivateKey = hex.EncodeToString(privateKey.Seed())
+	}
+	return result
+}
+
+func runKeygen(args []string) error {
+	fs := newFlagSet("keygen", "")
+	out := fs.String("out", "", "File to write the private key to. If empty, the private key is printed.")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		if err := ioutil.WriteFile(*out, []byte(hex.EncodeToString(privateKey.Seed())), 0600); err != nil {
+			return err
+		}
+	}
+	kp := newKeyPair(privateKey, *out == "")
+	t := keyValueTable(
+		"Address", kp.Address.String(),
+		"Public key", kp.PublicKey,
+	)
+	if kp.PrivateKey != "" {
+		t.rows = append(t.rows, []string{"Private key:", kp.PrivateKey})
+	}
+	return printResult(kp, t)
+}
+
+// walletOutput is the output of the wallet commands.
+type walletOutput struct {
+	Mnemonic string    `json:"mnemonic,omitempty"`
+	Accounts []keyPair `json:"accounts"`
+}
+
+func runWalletNew(args []string) error {
+	fs := newFlagSet("wallet new", "")
+	recoveryFile := fs.String("recovery", "", "File to write the recovery data (mnemonic and account count) to. If empty, the mnemonic is printed.")
+	accounts := fs.Uint64("accounts", 1, "Number of accounts to create")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	w, err := wallet.New()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < *accounts; i++ {
+		if _, err := w.NewAccount(); err != nil {
+			return err
+		}
+	}
+	out := walletOutput{}
+	if *recoveryFile != "" {
+		if err := w.WriteRecovery(*recoveryFile); err != nil {
+			return err
+		}
+	} else {
+		out.Mnemonic = w.Mnemonic()
+	}
+	return printWallet(w, out)
+}
+
+func runWalletRecover(args []string) error {
+	fs := newFlagSet("wallet recover", "<recovery file>")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	w, err := wallet.Recover(args[0])
+	if err != nil {
+		return err
+	}
+	return printWallet(w, walletOutput{})
+}
+
+// printWallet prints the wallet's accounts, with the mnemonic if it's set in the output.
+func printWallet(w *wallet.Wallet, out walletOutput) error {
+	accounts, err := w.Accounts()
+	if err != nil {
+		return err
+	}
+	t := table{
+		header: []string{"INDEX", "ADDRESS", "PUBLIC KEY"},
+	}
+	for _, acc := range accounts {
+		index := acc.Index
+		kp := newKeyPair(acc.PrivateKey, false)
+		kp.Index = &index
+		out.Accounts = append(out.Accounts, kp)
+		t.rows = append(t.rows, []string{strconv.FormatUint(index, 10), kp.Address.String(), kp.PublicKey})
+	}
+	if out.Mnemonic != "" && *output != "json" {
+		fmt.Printf("Mnemonic (keep it secret!): %v\n\n", out.Mnemonic)
+	}
+	return printResult(out, t)
+}
+
+func runSign(args []string) error {
+	fs := newFlagSet("sign", "<unsigned transaction file>")
+	kf := addKeyFlags(fs)
+	out := fs.String("out", "", "File to write the signed transaction to. If empty, it's printed.")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	unsignedTx, err := readUnsignedTransaction(args[0])
+	if err != nil {
+		return err
+	}
+	signer, err := kf.signer()
+	if err != nil {
+		return err
+	}
+	tx, err := unsignedTx.Sign(signer)
+	if err != nil {
+		return err
+	}
+	summarizedTx := libra.SummarizedTransaction{
+		Transaction: tx,
+		Summary:     unsignedTx.Summary,
+	}
+	if *out != "" {
+		if err := writeJSONFile(*out, summarizedTx); err != nil {
+			return err
+		}
+	}
+	return printSummary(summarizedTx, summarizedTx.String())
+}
+
+// readUnsignedTransaction reads an unsigned transaction file as written by "libra prepare-transfer".
+// The file can also contain a hex encoded raw transaction, in which case the summary is created from it.
+func readUnsignedTransaction(path string) (libra.UnsignedTransaction, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return libra.UnsignedTransaction{}, err
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var unsignedTx libra.UnsignedTransaction
+		err := json.Unmarshal(data, &unsignedTx)
+		return unsignedTx, err
+	}
+	rawTxBytes, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
+	if err != nil {
+		return libra.UnsignedTransaction{}, err
+	}
+	rawTx, err := libra.RawTransactionFromBytes(rawTxBytes)
+	if err != nil {
+		return libra.UnsignedTransaction{}, err
+	}
+	return libra.NewUnsignedTransaction(rawTx)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// nodeHealth is the JSON output of the ledger command for a node.
+type nodeHealth struct {
+	Address    string            `json:"address"`
+	LedgerInfo *libra.LedgerInfo `json:"ledger_info,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+func runLedger(args []string) error {
+	fs := newFlagSet("ledger", "")
+	maxVersionLag := fs.Uint64("max-version-lag", libra.DefaultHealthCheck.MaxVersionLag, "Maximum number of versions a node can be behind the other nodes, 0 disables the check")
+	maxAge := fs.Duration("max-age", libra.DefaultHealthCheck.MaxAge, "Maximum age of a node's latest ledger info, 0 disables the check")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	defer cancel()
+	h := libra.HealthCheck{MaxVersionLag: *maxVersionLag, MaxAge: *maxAge}
+	result := h.Check(ctx, c)
+
+	var nodes []nodeHealth
+	t := table{header: []string{"Address", "Version", "Epoch", "Timestamp", "Signers", "Status"}}
+	unhealthy := 0
+	for _, nh := range result {
+		node := nodeHealth{Address: nh.Address}
+		row := []string{nh.Address, "", "", "", "", "OK"}
+		if nh.Err != nil {
+			unhealthy++
+			node.Error = nh.Err.Error()
+			row[5] = nh.Err.Error()
+		}
+		if _, ok := nh.Err.(libra.LagError); ok || nh.Err == nil {
+			ledgerInfo := nh.LedgerInfo
+			node.LedgerInfo = &ledgerInfo
+			row[1] = strconv.FormatUint(ledgerInfo.Version, 10)
+			row[2] = strconv.FormatUint(ledgerInfo.Epoch, 10)
+			row[3] = ledgerInfo.Timestamp.Format(time.RFC3339)
+			row[4] = strconv.Itoa(len(ledgerInfo.Signers))
+		}
+		nodes = append(nodes, node)
+		t.rows = append(t.rows, row)
+	}
+	if err := printResult(nodes, t); err != nil {
+		return err
+	}
+	if unhealthy > 0 {
+		return fmt.Errorf("%v of %v nodes are unhealthy", unhealthy, len(result))
+	}
+	return nil
+}
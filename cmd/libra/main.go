@@ -0,0 +1,249 @@
+// Command libra is a command-line tool for Libra, similar to the Libra CLI.
+//
+// Usage:
+//
+//	libra [flags] <command> [<subcommand>] [flags] [arguments]
+//
+// Commands:
+//
+//	account state|balance|sequence <address>   Show an account's state, balance or sequence number
+//	tx by-seq <address> <sequence number>       Show the transaction of an account with a sequence number
+//	tx by-version <version>                     Show transactions starting at a version
+//	events sent|received <address>              List an account's sent or received payment events
+//	keygen                                      Generate a new key pair
+//	wallet new|recover                          Create a new wallet or recover one from a recovery file
+//	mint <address> <amount>                     Mint Libra Coins with the faucet of the testnet
+//	transfer <receiver> <amount>                Transfer Libra Coins
+//	prepare-transfer <receiver> <amount>        Create an unsigned transfer transaction file for offline signing
+//	sign <unsigned transaction file>            Sign an unsigned transaction file, e.g. on an offline machine
+//	submit <signed transaction file>            Verify and send a signed transaction file
+//	disassemble <file>                          Disassemble a compiled Move script or module, or with -tx the program of a transaction
+//	ledger                                      Show the latest ledger info of each node and flag nodes that are lagging behind
+//
+// Run "libra <command> -h" for the flags of a command.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// Global flags
+var (
+	addr       = flag.String("addr", "ac.testnet.libra.org:8000", "Address of the validator node, or multiple comma-separated addresses for failover")
+	output     = flag.String("output", "table", "Output format: \"table\" or \"json\"")
+	timeout    = flag.Duration("timeout", 10*time.Second, "Timeout for connecting to the validator node and for requests")
+	scriptsDir = flag.String("scripts", "", "Directory with compiled transaction scripts, e.g. \"peer_to_peer_transfer.mv\"")
+)
+
+// healthCheckInterval is the interval of the health checks when multiple addresses are given.
+const healthCheckInterval = 10 * time.Second
+
+// command is a (sub)command of the tool.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+	// subcommands are used if run is nil
+	subcommands []command
+}
+
+var commands = []command{
+	{name: "account", subcommands: []command{
+		{name: "state", usage: "<address>", run: runAccountState},
+		{name: "balance", usage: "<address>", run: runAccountBalance},
+		{name: "sequence", usage: "<address>", run: runAccountSequence},
+	}},
+	{name: "tx", subcommands: []command{
+		{name: "by-seq", usage: "<address> <sequence number>", run: runTxBySeq},
+		{name: "by-version", usage: "<version>", run: runTxByVersion},
+	}},
+	{name: "events", subcommands: []command{
+		{name: "sent", usage: "<address>", run: runEventsSent},
+		{name: "received", usage: "<address>", run: runEventsReceived},
+	}},
+	{name: "keygen", usage: "", run: runKeygen},
+	{name: "wallet", subcommands: []command{
+		{name: "new", usage: "", run: runWalletNew},
+		{name: "recover", usage: "<recovery file>", run: runWalletRecover},
+	}},
+	{name: "mint", usage: "<address> <amount>", run: runMint},
+	{name: "transfer", usage: "<receiver> <amount>", run: runTransfer},
+	{name: "prepare-transfer", usage: "-sender <address> <receiver> <amount>", run: runPrepareTransfer},
+	{name: "sign", usage: "<unsigned transaction file>", run: runSign},
+	{name: "submit", usage: "<signed transaction file>", run: runSubmit},
+	{name: "disassemble", usage: "<file> | -tx <address> <sequence number>", run: runDisassemble},
+	{name: "ledger", usage: "", run: runLedger},
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *output != "table" && *output != "json" {
+		fail(fmt.Errorf("Invalid output format: %q", *output))
+	}
+	if *scriptsDir != "" {
+		if _, err := libra.LoadScripts(*scriptsDir); err != nil {
+			fail(err)
+		}
+	}
+
+	args := flag.Args()
+	cmds := commands
+	var path []string
+	for {
+		if len(args) == 0 {
+			usage()
+			os.Exit(2)
+		}
+		cmd, ok := findCommand(cmds, args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown command: %v\n", strings.Join(append(path, args[0]), " "))
+			usage()
+			os.Exit(2)
+		}
+		path = append(path, args[0])
+		args = args[1:]
+		if cmd.run != nil {
+			err := cmd.run(args)
+			if err == flag.ErrHelp {
+				os.Exit(2)
+			} else if err != nil {
+				fail(err)
+			}
+			return
+		}
+		cmds = cmd.subcommands
+	}
+}
+
+func findCommand(cmds []command, name string) (command, bool) {
+	for _, cmd := range cmds {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: libra [flags] <command> [<subcommand>] [flags] [arguments]\n\nCommands:\n")
+	for _, cmd := range commands {
+		if cmd.run != nil {
+			fmt.Fprintf(os.Stderr, "  %v %v\n", cmd.name, cmd.usage)
+		}
+		for _, sub := range cmd.subcommands {
+			fmt.Fprintf(os.Stderr, "  %v %v %v\n", cmd.name, sub.name, sub.usage)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
+
+// newFlagSet creates a flag set for a command with the given usage.
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: libra %v [flags] %v\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses the flags of a command and checks the number of remaining arguments.
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() != n {
+		fs.Usage()
+		return nil, flag.ErrHelp
+	}
+	return fs.Args(), nil
+}
+
+// connect creates a client that's connected to the validator node(s).
+func connect() (libra.Client, error) {
+	if strings.Contains(*addr, ",") {
+		return libra.NewMultiNodeClient(strings.Split(*addr, ","), *timeout, healthCheckInterval)
+	}
+	return libra.NewClient(*addr, *timeout)
+}
+
+// newHTTPClient creates an HTTP client with the request timeout.
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: *timeout}
+}
+
+// requestContext returns a context with the request timeout.
+func requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), *timeout)
+}
+
+// table is the table output of a command.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// printResult prints v as JSON or the table, depending on the output flag.
+func printResult(v interface{}, t table) error {
+	if *output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if t.header != nil {
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// keyValueTable creates a table with one row per key-value pair.
+func keyValueTable(kvs ...string) table {
+	if len(kvs)%2 != 0 {
+		panic("odd number of key-value arguments")
+	}
+	t := table{}
+	for i := 0; i < len(kvs); i += 2 {
+		t.rows = append(t.rows, []string{kvs[i] + ":", kvs[i+1]})
+	}
+	return t
+}
+
+// printSummary prints v as JSON or the given text, depending on the output flag.
+func printSummary(v interface{}, text string) error {
+	if *output == "json" {
+		return printResult(v, table{})
+	}
+	_, err := fmt.Println(text)
+	return err
+}
+
+// writeJSONFile writes v as indented JSON into a file.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0600)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strconv"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/faucet"
+)
+
+func runMint(args []string) error {
+	fs := newFlagSet("mint", "<address> <amount>")
+	faucetURL := fs.String("faucet", faucet.DefaultURL, "URL of the faucet")
+	args, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	receiver, err := libra.ParseAccountAddress(args[0])
+	if err != nil {
+		return err
+	}
+	amount, err := libra.ParseAmount(args[1])
+	if err != nil {
+		return err
+	}
+
+	fc := faucet.NewClient(*faucetURL)
+	fc.HTTPClient = newHTTPClient()
+	seqNo, err := fc.Mint(receiver, amount)
+	if err != nil {
+		return err
+	}
+	return printResult(struct {
+		SequenceNo uint64 `json:"association_sequence_number,string"`
+	}{seqNo}, keyValueTable("Association sequence number", strconv.FormatUint(seqNo, 10)))
+}
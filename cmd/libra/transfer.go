@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// defaultTxTTL is how long a transaction is valid, the same as in the Libra CLI.
+const defaultTxTTL = libra.DefaultTransactionTTL
+
+// transferOutput is the output of the transfer and submit commands.
+type transferOutput struct {
+	Sender     libra.AccountAddress `json:"sender"`
+	SequenceNo uint64               `json:"sequence_number,string"`
+	Result     *transferResult      `json:"result,omitempty"`
+}
+
+// transferResult is the result of a committed transfer.
+type transferResult struct {
+	Version uint64 `json:"version,string"`
+	GasUsed uint64 `json:"gas_used,string"`
+}
+
+// transferFlags are the flags of commands that create transfer transactions.
+type transferFlags struct {
+	maxGasAmount *uint64
+	gasUnitPrice *uint64
+	ttl          *time.Duration
+}
+
+func addTransferFlags(fs *flag.FlagSet, ttl time.Duration) transferFlags {
+	return transferFlags{
+		maxGasAmount: fs.Uint64("max-gas", 0, "Max gas amount. If 0, it's estimated."),
+		gasUnitPrice: fs.Uint64("gas-price", 0, "Gas unit price in micro-libra"),
+		ttl:          fs.Duration("ttl", ttl, "Time until the transaction expires, relative to the ledger's timestamp"),
+	}
+}
+
+// client returns a copy of the client whose transaction helpers use the fee and TTL from the flags.
+func (tf transferFlags) client(c libra.Client) libra.Client {
+	if *tf.maxGasAmount != 0 {
+		c = c.WithFee(libra.Fee{
+			MaxGasAmount: *tf.maxGasAmount,
+			GasUnitPrice: libra.Amount(*tf.gasUnitPrice),
+		})
+	} else {
+		policy := libra.DefaultFeePolicy
+		policy.Default.GasUnitPrice = libra.Amount(*tf.gasUnitPrice)
+		c = c.WithFeePolicy(policy)
+	}
+	return c.WithTransactionTTL(*tf.ttl)
+}
+
+// newTransfer creates a transfer transaction with the sender's current sequence number and the fee from the flags
+// or an estimated one, and checks if the sender's balance covers it.
+func (tf transferFlags) newTransfer(c libra.Client, sender libra.AccountAddress, args []string) (libra.RawTransaction, error) {
+	receiver, err := libra.ParseAccountAddress(args[0])
+	if err != nil {
+		return libra.RawTransaction{}, err
+	}
+	amount, err := libra.ParseAmount(args[1])
+	if err != nil {
+		return libra.RawTransaction{}, err
+	}
+	script, err := libra.GetScript(libra.ScriptPeerToPeerTransfer)
+	if err != nil {
+		return libra.RawTransaction{}, err
+	}
+	ctx, cancel := requestContext()
+	defer cancel()
+
+	accState, err := c.GetAccountStateContext(ctx, sender.String())
+	if err != nil {
+		return libra.RawTransaction{}, err
+	}
+	fee := libra.Fee{
+		MaxGasAmount: *tf.maxGasAmount,
+		GasUnitPrice: libra.Amount(*tf.gasUnitPrice),
+	}
+	if fee.MaxGasAmount == 0 {
+		policy := libra.DefaultFeePolicy
+		policy.Default.GasUnitPrice = fee.GasUnitPrice
+		if fee, err = c.EstimateFee(ctx, script.Code, policy); err != nil {
+			return libra.RawTransaction{}, err
+		}
+	}
+	if err := fee.Validate(); err != nil {
+		return libra.RawTransaction{}, err
+	}
+	if err := c.CheckBalance(ctx, sender, fee, amount); err != nil {
+		return libra.RawTransaction{}, err
+	}
+
+	rawTx, err := libra.NewTransferTransaction(sender, accState.AccountResource.SequenceNo, receiver, amount, fee)
+	if err != nil {
+		return libra.RawTransaction{}, err
+	}
+	// The TTL is relative to the ledger's timestamp, because the local clock can differ from the validators' clocks
+	expiration, err := c.Expiration(ctx, *tf.ttl)
+	if err != nil {
+		return libra.RawTransaction{}, err
+	}
+	rawTx.SetExpiration(expiration)
+	return rawTx, nil
+}
+
+func runTransfer(args []string) error {
+	fs := newFlagSet("transfer", "<receiver> <amount>")
+	kf := addKeyFlags(fs)
+	tf := addTransferFlags(fs, defaultTxTTL)
+	wait := fs.Bool("wait", false, "Wait until the transaction is committed")
+	args, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	receiver, err := libra.ParseAccountAddress(args[0])
+	if err != nil {
+		return err
+	}
+	amount, err := libra.ParseAmount(args[1])
+	if err != nil {
+		return err
+	}
+	signer, err := kf.signer()
+	if err != nil {
+		return err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	seqNo, err := tf.client(c).Transfer(ctx, signer, receiver, amount)
+	cancel()
+	if err != nil {
+		return err
+	}
+	// The transaction expires the TTL after the ledger's timestamp
+	return printSent(c, libra.SenderOf(signer), seqNo, *tf.ttl, *wait)
+}
+
+func runPrepareTransfer(args []string) error {
+	fs := newFlagSet("prepare-transfer", "<receiver> <amount>")
+	senderFlag := fs.String("sender", "", "Address of the sender")
+	out := fs.String("out", "", "File to write the unsigned transaction to. If empty, it's printed.")
+	tf := addTransferFlags(fs, time.Hour)
+	args, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	sender, err := libra.ParseAccountAddress(*senderFlag)
+	if err != nil {
+		return err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	rawTx, err := tf.newTransfer(c, sender, args)
+	if err != nil {
+		return err
+	}
+	unsignedTx, err := libra.NewUnsignedTransaction(rawTx)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		if err := writeJSONFile(*out, unsignedTx); err != nil {
+			return err
+		}
+	}
+	return printSummary(unsignedTx, unsignedTx.Summary.String())
+}
+
+func runSubmit(args []string) error {
+	fs := newFlagSet("submit", "<signed transaction file>")
+	wait := fs.Bool("wait", false, "Wait until the transaction is committed")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	var summarizedTx libra.SummarizedTransaction
+	if err := json.Unmarshal(data, &summarizedTx); err != nil {
+		return err
+	}
+	if err := summarizedTx.Verify(); err != nil {
+		return err
+	}
+	rawTx, err := libra.RawTransactionFromBytes(summarizedTx.Transaction.RawBytes)
+	if err != nil {
+		return err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	// Signed transaction files can be old, so expired transactions are detected before sending them
+	ctx, cancel := requestContext()
+	defer cancel()
+	if err := c.CheckExpiration(ctx, summarizedTx.Transaction); err != nil {
+		return err
+	}
+	return send(c, summarizedTx.Transaction, rawTx, *wait)
+}
+
+// send sends the transaction and prints the result.
+func send(c libra.Client, tx libra.Transaction, rawTx libra.RawTransaction, wait bool) error {
+	ctx, cancel := requestContext()
+	defer cancel()
+	if err := c.SendTxContext(ctx, tx); err != nil {
+		return err
+	}
+	// The expiration time refers to the ledger's clock, which can differ from the local one
+	ttl := defaultTxTTL
+	if rawTx.ExpirationTime != 0 {
+		ledgerTime, err := c.LedgerTime(ctx)
+		if err != nil {
+			return err
+		}
+		ttl = rawTx.Expiration().Sub(ledgerTime)
+	}
+	return printSent(c, rawTx.Sender, rawTx.SequenceNo, ttl, wait)
+}
+
+// printSent prints the sender and sequence number of a sent transaction.
+// If wait is true, it waits until the transaction is committed, at most until the transaction's remaining TTL passed,
+// and prints the result.
+func printSent(c libra.Client, sender libra.AccountAddress, seqNo uint64, ttl time.Duration, wait bool) error {
+	out := transferOutput{
+		Sender:     sender,
+		SequenceNo: seqNo,
+	}
+	t := keyValueTable(
+		"Sender", sender.String(),
+		"Sequence number", strconv.FormatUint(seqNo, 10),
+	)
+	if wait {
+		// Waiting can take longer than a request, but not longer than the transaction is valid.
+		// The client sent the transaction, so WaitForTransaction(...) stops at its expiration on the ledger.
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), ttl+*timeout)
+		defer waitCancel()
+		res, err := c.WaitForTransaction(waitCtx, sender, seqNo)
+		if err != nil {
+			return err
+		}
+		out.Result = &transferResult{
+			Version: res.Version,
+			GasUsed: res.GasUsed,
+		}
+		t.rows = append(t.rows,
+			[]string{"Version:", strconv.FormatUint(res.Version, 10)},
+			[]string{"Gas used:", strconv.FormatUint(res.GasUsed, 10)},
+		)
+	}
+	return printResult(out, t)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+func runTxBySeq(args []string) error {
+	fs := newFlagSet("tx by-seq", "<address> <sequence number>")
+	fetchEvents := fs.Bool("events", false, "Also show the events of the transaction")
+	args, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	sender, err := libra.ParseAccountAddress(args[0])
+	if err != nil {
+		return err
+	}
+	seqNo, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	defer cancel()
+	tx, err := c.GetAccountTransaction(ctx, sender, seqNo, *fetchEvents)
+	if err != nil {
+		return err
+	}
+	return printResult(tx, txTable([]libra.CommittedTransaction{tx}))
+}
+
+func runTxByVersion(args []string) error {
+	fs := newFlagSet("tx by-version", "<version>")
+	limit := fs.Uint64("limit", 1, "Maximum number of transactions to show")
+	fetchEvents := fs.Bool("events", false, "Also show the events of the transactions")
+	args, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	version, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	c, err := connect()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	ctx, cancel := requestContext()
+	defer cancel()
+	txs, err := c.GetTransactions(ctx, version, *limit, *fetchEvents)
+	if err != nil {
+		return err
+	}
+	return printResult(txs, txTable(txs))
+}
+
+// txTable creates a table with one row per transaction.
+// Transactions that can't be decoded only show their version and gas used.
+func txTable(txs []libra.CommittedTransaction) table {
+	t := table{
+		header: []string{"VERSION", "SENDER", "SEQUENCE NUMBER", "SCRIPT", "GAS USED", "EVENTS"},
+	}
+	for _, tx := range txs {
+		sender, seqNo, script := "", "", ""
+		if decodedTx, err := tx.Transaction.Decode(); err == nil {
+			sender = decodedTx.RawTransaction.Sender.String()
+			seqNo = strconv.FormatUint(decodedTx.RawTransaction.SequenceNo, 10)
+			script = decodedTx.Script
+			if script == "" && decodedTx.ScriptHash != nil {
+				script = hex.EncodeToString(decodedTx.ScriptHash)
+			} else if decodedTx.WriteSet != nil {
+				script = "write set (" + strconv.Itoa(len(decodedTx.WriteSet)) + " ops)"
+			}
+		}
+		t.rows = append(t.rows, []string{
+			strconv.FormatUint(tx.Version, 10),
+			sender,
+			seqNo,
+			script,
+			strconv.FormatUint(tx.Info.GasUsed, 10),
+			strconv.Itoa(len(tx.Events)),
+		})
+	}
+	return t
+}
@@ -0,0 +1,35 @@
+package libra
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrAccountAlreadyExists is returned by Client.CreateAccount(...) when the account to be created already exists.
+// The VM would abort the transaction with the runtime status AccountAddressAlreadyExists.
+var ErrAccountAlreadyExists = errors.New("The account already exists (AccountAddressAlreadyExists)")
+
+// CreateAccount sends a transaction with the create_account script,
+// which creates the account with the given address and transfers the initial amount from the signer's account to it.
+// The script must be registered, see RegisterScript(...).
+//
+// The transaction gets the sender's current sequence number and an estimated fee, like Transfer(...).
+// The fee can be set via Client.WithFee(...).
+// For concurrent calls with the same signer, e.g. a treasury account, set a SequenceManager via Client.WithSequenceManager(...).
+// Before sending it, the balance of the sender is checked and ErrAccountAlreadyExists is returned
+// if the account already exists.
+// The sequence number of the sent transaction is returned, which can be used for WaitForTransaction(...).
+func (c Client) CreateAccount(ctx context.Context, signer Signer, newAddr AccountAddress, initialAmount Amount) (uint64, error) {
+	if _, err := c.GetAccountStateContext(ctx, newAddr.String()); err == nil {
+		return 0, ErrAccountAlreadyExists
+	} else if err != ErrAccountNotFound {
+		return 0, err
+	}
+	script, err := GetScript(ScriptCreateAccount)
+	if err != nil {
+		return 0, err
+	}
+	return c.submitScript(ctx, signer, script.Code, initialAmount, func(sender AccountAddress, seqNo uint64, fee Fee) (RawTransaction, error) {
+		return NewCreateAccountTransaction(sender, seqNo, newAddr, initialAmount, fee)
+	})
+}
@@ -0,0 +1,126 @@
+package libra_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+)
+
+// TestCreateAccount tests if libra.Client.CreateAccount(...) creates and funds an account
+// and checks if it already exists and if the sender's balance is sufficient.
+func TestCreateAccount(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptCreateAccount,
+		Code: []byte{10, 11, 12},
+	})
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.VerifySignatures = true
+	s.SetAccount(sender, libra.AccountResource{Balance: 10 * libra.Libra, SequenceNo: 3, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	newAddr := libra.AccountAddress{5}
+	seqNo, err := c.CreateAccount(context.Background(), signer, newAddr, 4*libra.Libra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seqNo != 3 {
+		t.Fatalf("Expected sequence number 3, but was %v", seqNo)
+	}
+	accState, err := c.GetAccountState(newAddr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accState.AccountResource.Balance != 4*libra.Libra {
+		t.Fatalf("Expected a balance of 4 LBR, but was %v LBR", accState.AccountResource.Balance)
+	}
+
+	if _, err := c.CreateAccount(context.Background(), signer, newAddr, libra.Libra); err != libra.ErrAccountAlreadyExists {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAccountAlreadyExists, err)
+	}
+	_, err = c.CreateAccount(context.Background(), signer, libra.AccountAddress{6}, 7*libra.Libra)
+	if _, ok := err.(libra.InsufficientBalanceError); !ok {
+		t.Fatalf("Expected an InsufficientBalanceError, but was %v", err)
+	}
+}
+
+// TestCreateAccountConcurrently tests if concurrent libra.Client.CreateAccount(...) calls of the same sender
+// get unique sequence numbers from the client's SequenceManager.
+func TestCreateAccountConcurrently(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptCreateAccount,
+		Code: []byte{10, 11, 12},
+	})
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.VerifySignatures = true
+	s.SetAccount(sender, libra.AccountResource{Balance: 100 * libra.Libra, SequenceNo: 3, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c = c.WithSequenceManager(libra.NewSequenceManager(c))
+
+	const count = 10
+	seqNos := make(chan uint64, count)
+	wg := sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(newAddr libra.AccountAddress) {
+			defer wg.Done()
+			seqNo, err := c.CreateAccount(context.Background(), signer, newAddr, libra.Libra)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			seqNos <- seqNo
+		}(libra.AccountAddress{byte(100 + i)})
+	}
+	wg.Wait()
+	close(seqNos)
+
+	seen := make(map[uint64]bool, count)
+	for seqNo := range seqNos {
+		if seen[seqNo] {
+			t.Fatalf("Sequence number %v was used twice", seqNo)
+		}
+		seen[seqNo] = true
+	}
+	if len(seen) != count {
+		t.Fatalf("Expected %v created accounts, but were %v", count, len(seen))
+	}
+	if accRes, _ := s.Account(sender); accRes.SequenceNo != 3+count {
+		t.Fatalf("Expected the sequence number on the ledger to be %v, but was %v", 3+count, accRes.SequenceNo)
+	}
+}
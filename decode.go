@@ -0,0 +1,170 @@
+package libra
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// DecodedTransaction is a signed transaction with its decoded raw transaction,
+// e.g. of a transaction that was returned by a query.
+type DecodedTransaction struct {
+	RawTransaction RawTransaction
+	SenderPubKey   []byte
+	SenderSig      []byte
+	// Script is the name of the registered script with the same bytecode hash as the program,
+	// e.g. ScriptPeerToPeerTransfer. It's empty if no such script is registered.
+	Script string
+	// ScriptHash is the SHA3-256 hash of the program's bytecode
+	ScriptHash []byte
+	// Arguments are the decoded arguments of the program, see TransactionArgument.Decode()
+	Arguments []interface{}
+	// WriteSet contains the decoded ops of write set transactions, see WriteSet.Decode()
+	WriteSet []DecodedWriteOp
+}
+
+// Decode decodes the raw transaction of the signed transaction and its program's arguments,
+// and recognizes registered scripts by their bytecode hash.
+// For write set transactions, the write set is decoded instead.
+func (tx Transaction) Decode() (DecodedTransaction, error) {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return DecodedTransaction{}, err
+	}
+	result := DecodedTransaction{
+		RawTransaction: rawTx,
+		SenderPubKey:   tx.SenderPubKey,
+		SenderSig:      tx.SenderSig,
+	}
+	if rawTx.WriteSet != nil {
+		result.WriteSet = rawTx.WriteSet.Decode()
+	}
+	if rawTx.Program == nil {
+		return result, nil
+	}
+	result.ScriptHash = hashing.SHA3(rawTx.Program.Code)
+	if script, ok := ScriptByHash(result.ScriptHash); ok {
+		result.Script = script.Name
+	}
+	for i, arg := range rawTx.Program.Arguments {
+		v, err := arg.Decode()
+		if err != nil {
+			return DecodedTransaction{}, fmt.Errorf("Invalid argument %v: %v", i, err)
+		}
+		result.Arguments = append(result.Arguments, v)
+	}
+	return result, nil
+}
+
+// Decode returns the argument's value as Go value, depending on its type:
+// uint64 for U64, AccountAddress for ADDRESS, string for STRING and []byte for BYTEARRAY.
+func (arg TransactionArgument) Decode() (interface{}, error) {
+	switch arg.Type {
+	case types.TransactionArgument_U64:
+		if len(arg.Data) != 8 {
+			return nil, fmt.Errorf("Invalid data length of U64 argument: %v bytes", len(arg.Data))
+		}
+		return binary.LittleEndian.Uint64(arg.Data), nil
+	case types.TransactionArgument_ADDRESS:
+		return AccountAddressFromBytes(arg.Data)
+	case types.TransactionArgument_STRING:
+		if !utf8.Valid(arg.Data) {
+			return nil, errors.New("Invalid UTF-8 in STRING argument")
+		}
+		return string(arg.Data), nil
+	case types.TransactionArgument_BYTEARRAY:
+		return arg.Data, nil
+	default:
+		return nil, fmt.Errorf("Unknown argument type: %v", arg.Type)
+	}
+}
+
+// decodedTransactionJSON is the JSON representation of a DecodedTransaction.
+type decodedTransactionJSON struct {
+	Sender         AccountAddress `json:"sender"`
+	SequenceNo     uint64         `json:"sequence_number,string"`
+	Script         string         `json:"script,omitempty"`
+	ScriptHash     hexBytes       `json:"script_hash,omitempty"`
+	Arguments      []argumentJSON `json:"arguments"`
+	Modules        int            `json:"modules"`
+	WriteSet       []writeOpJSON  `json:"write_set,omitempty"`
+	MaxGasAmount   uint64         `json:"max_gas_amount,string"`
+	GasUnitPrice   uint64         `json:"gas_unit_price,string"`
+	ExpirationTime uint64         `json:"expiration_time,string"`
+	SenderPubKey   hexBytes       `json:"sender_public_key"`
+	SenderSig      hexBytes       `json:"sender_signature"`
+}
+
+// argumentJSON is the JSON representation of a decoded transaction argument.
+// The value is a string for all types, encoded like the addresses and byte slices in the other JSON encodings.
+type argumentJSON struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// writeOpJSON is the JSON representation of a DecodedWriteOp.
+type writeOpJSON struct {
+	AccessPath      string           `json:"access_path"`
+	Type            string           `json:"type"`
+	Value           hexBytes         `json:"value,omitempty"`
+	AccountResource *AccountResource `json:"account_resource,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The JSON encoding is meant for showing the transaction.
+// Use the JSON encoding of Transaction for exchanging it.
+func (dt DecodedTransaction) MarshalJSON() ([]byte, error) {
+	rawTx := dt.RawTransaction
+	v := decodedTransactionJSON{
+		Sender:         rawTx.Sender,
+		SequenceNo:     rawTx.SequenceNo,
+		Script:         dt.Script,
+		ScriptHash:     dt.ScriptHash,
+		Arguments:      []argumentJSON{},
+		MaxGasAmount:   rawTx.MaxGasAmount,
+		GasUnitPrice:   rawTx.GasUnitPrice.MicroLibra(),
+		ExpirationTime: rawTx.ExpirationTime,
+		SenderPubKey:   dt.SenderPubKey,
+		SenderSig:      dt.SenderSig,
+	}
+	if rawTx.Program != nil {
+		v.Modules = len(rawTx.Program.Modules)
+	}
+	for _, arg := range dt.Arguments {
+		v.Arguments = append(v.Arguments, newArgumentJSON(arg))
+	}
+	for _, op := range dt.WriteSet {
+		opJSON := writeOpJSON{
+			AccessPath: formatAccessPath(op.AccessPath),
+			Type:       op.Type.String(),
+			Value:      op.Value,
+		}
+		if accRes, ok := op.DecodedValue.(AccountResource); ok {
+			opJSON.AccountResource = &accRes
+		}
+		v.WriteSet = append(v.WriteSet, opJSON)
+	}
+	return json.Marshal(v)
+}
+
+func newArgumentJSON(arg interface{}) argumentJSON {
+	switch v := arg.(type) {
+	case uint64:
+		return argumentJSON{Type: types.TransactionArgument_U64.String(), Value: strconv.FormatUint(v, 10)}
+	case AccountAddress:
+		return argumentJSON{Type: types.TransactionArgument_ADDRESS.String(), Value: v.String()}
+	case string:
+		return argumentJSON{Type: types.TransactionArgument_STRING.String(), Value: v}
+	case []byte:
+		text, _ := hexBytes(v).MarshalText()
+		return argumentJSON{Type: types.TransactionArgument_BYTEARRAY.String(), Value: string(text)}
+	default:
+		return argumentJSON{Value: fmt.Sprint(v)}
+	}
+}
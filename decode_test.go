@@ -0,0 +1,86 @@
+package libra_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestDecodeTransaction tests if a transfer transaction is decoded with its script name and typed arguments.
+func TestDecodeTransaction(t *testing.T) {
+	sender, receiver := libra.AccountAddress{1}, libra.AccountAddress{2}
+	tx := newTestTransfer(t, sender, 7, receiver, 3*libra.Libra)
+	decodedTx, err := tx.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedTx.RawTransaction.Sender != sender || decodedTx.RawTransaction.SequenceNo != 7 {
+		t.Fatalf("Expected sender %v and sequence number 7, but was %v and %v", sender, decodedTx.RawTransaction.Sender, decodedTx.RawTransaction.SequenceNo)
+	}
+	if decodedTx.Script != libra.ScriptPeerToPeerTransfer {
+		t.Fatalf("Expected script %v, but was %q", libra.ScriptPeerToPeerTransfer, decodedTx.Script)
+	}
+	if len(decodedTx.Arguments) != 2 || decodedTx.Arguments[0] != receiver || decodedTx.Arguments[1] != (3*libra.Libra).MicroLibra() {
+		t.Fatalf("Expected the receiver and amount as arguments, but was %v", decodedTx.Arguments)
+	}
+
+	b, err := json.Marshal(decodedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"script":"peer_to_peer_transfer"`) || !strings.Contains(string(b), `{"type":"U64","value":"3000000"}`) {
+		t.Fatalf("Unexpected JSON encoding: %s", b)
+	}
+}
+
+// TestDecodeTransactionUnknownScript tests if transactions with unregistered scripts are decoded without script name.
+func TestDecodeTransactionUnknownScript(t *testing.T) {
+	rawTxBytes, err := newTestRawTx(libra.AccountAddress{1}).Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx, _ := libra.RawTransactionFromBytes(rawTxBytes)
+	rawTx.Program.Code = []byte{9, 9, 9}
+	rawTxBytes, err = rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedTx, err := libra.Transaction{RawBytes: rawTxBytes}.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedTx.Script != "" || len(decodedTx.ScriptHash) != 32 {
+		t.Fatalf("Expected no script name and a script hash, but was %q and %x", decodedTx.Script, decodedTx.ScriptHash)
+	}
+}
+
+// TestDecodeArgument tests the decoding of all argument types.
+func TestDecodeArgument(t *testing.T) {
+	testCases := []struct {
+		arg      libra.TransactionArgument
+		expected interface{}
+	}{
+		{libra.TransactionArgument{Type: types.TransactionArgument_U64, Data: []byte{5, 0, 0, 0, 0, 0, 0, 0}}, uint64(5)},
+		{libra.TransactionArgument{Type: types.TransactionArgument_ADDRESS, Data: libra.AccountAddress{4}.Bytes()}, libra.AccountAddress{4}},
+		{libra.TransactionArgument{Type: types.TransactionArgument_STRING, Data: []byte("foo")}, "foo"},
+	}
+	for _, tc := range testCases {
+		v, err := tc.arg.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != tc.expected {
+			t.Fatalf("Expected %v, but was %v", tc.expected, v)
+		}
+	}
+	b, err := libra.TransactionArgument{Type: types.TransactionArgument_BYTEARRAY, Data: []byte{1, 2}}.Decode()
+	if err != nil || string(b.([]byte)) != string([]byte{1, 2}) {
+		t.Fatalf("Expected the byte array, but was %v (error: %v)", b, err)
+	}
+	if _, err := (libra.TransactionArgument{Type: types.TransactionArgument_U64, Data: []byte{1}}).Decode(); err == nil {
+		t.Fatal("Expected an error for an invalid U64 argument")
+	}
+}
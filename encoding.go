@@ -0,0 +1,32 @@
+package libra
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// hexBytes is a byte slice that's encoded as "0x"-prefixed hex string,
+// similar to how the Libra CLI prints keys, blobs and hashes.
+// When decoding, the "0x" prefix is optional.
+type hexBytes []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b hexBytes) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *hexBytes) UnmarshalText(text []byte) error {
+	decoded, err := decodeHex(string(text))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// decodeHex decodes a hex string with an optional "0x" prefix.
+func decodeHex(s string) ([]byte, error) {
+	s = strings.TrimPrefix(s, "0x")
+	return hex.DecodeString(s)
+}
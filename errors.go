@@ -0,0 +1,123 @@
+package libra
+
+import (
+	"fmt"
+
+	"github.com/philippgille/libra-sdk-go/rpc/admission_control"
+	"github.com/philippgille/libra-sdk-go/rpc/mempool"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// SubmitError is returned when a validator node didn't accept a transaction.
+// Exactly one of the statuses is set.
+type SubmitError struct {
+	// VMStatus is set when the transaction was rejected by the VM, e.g. due to an invalid signature.
+	VMStatus *types.VMStatus
+	// ACStatus is set when the transaction was rejected by admission control, e.g. due to the sender being blacklisted.
+	ACStatus *admission_control.AdmissionControlStatus
+	// MempoolStatus is set when the transaction was rejected by the mempool, e.g. because it's full.
+	MempoolStatus *mempool.MempoolAddTransactionStatus
+}
+
+// Error implements the error interface.
+func (e SubmitError) Error() string {
+	switch {
+	case e.VMStatus != nil:
+		return "The transaction was rejected by the VM: " + formatVMStatus(e.VMStatus)
+	case e.ACStatus != nil:
+		return fmt.Sprintf("The transaction was rejected by admission control: %v %v", e.ACStatus.GetCode(), e.ACStatus.GetMessage())
+	case e.MempoolStatus != nil:
+		return fmt.Sprintf("The transaction was rejected by the mempool: %v %v", e.MempoolStatus.GetCode(), e.MempoolStatus.GetMessage())
+	default:
+		return "The transaction was rejected"
+	}
+}
+
+// ValidationStatus returns the VM validation status code of the error.
+// If the transaction wasn't rejected during validation, types.VMValidationStatusCode_UnknownValidationStatus is returned.
+func (e SubmitError) ValidationStatus() types.VMValidationStatusCode {
+	return e.VMStatus.GetValidation().GetCode()
+}
+
+// formatVMStatus formats a VM status for error messages.
+func formatVMStatus(status *types.VMStatus) string {
+	switch errorType := status.GetErrorType().(type) {
+	case *types.VMStatus_Validation:
+		return fmt.Sprintf("validation status %v %v", errorType.Validation.GetCode(), errorType.Validation.GetMessage())
+	case *types.VMStatus_Verification:
+		return fmt.Sprintf("verification status %v", errorType.Verification.GetStatusList())
+	case *types.VMStatus_InvariantViolation:
+		return fmt.Sprintf("invariant violation %v", errorType.InvariantViolation)
+	case *types.VMStatus_Deserialization:
+		return fmt.Sprintf("binary error %v", errorType.Deserialization)
+	case *types.VMStatus_Execution:
+		return fmt.Sprintf("execution status %v", errorType.Execution)
+	default:
+		return "unknown status"
+	}
+}
+
+// submitResponseToError converts the response of a transaction submission into an error.
+// nil is returned if the transaction was accepted.
+func submitResponseToError(res *admission_control.SubmitTransactionResponse) error {
+	switch status := res.GetStatus().(type) {
+	case *admission_control.SubmitTransactionResponse_AcStatus:
+		if status.AcStatus.GetCode() == admission_control.AdmissionControlStatusCode_Accepted {
+			return nil
+		}
+		return SubmitError{ACStatus: status.AcStatus}
+	case *admission_control.SubmitTransactionResponse_MempoolStatus:
+		if status.MempoolStatus.GetCode() == mempool.MempoolAddTransactionStatusCode_Valid {
+			return nil
+		}
+		return SubmitError{MempoolStatus: status.MempoolStatus}
+	case *admission_control.SubmitTransactionResponse_VmStatus:
+		return SubmitError{VMStatus: status.VmStatus}
+	default:
+		return nil
+	}
+}
+
+// IsSequenceNumberError returns true if the error is a SubmitError that was caused by a sequence number
+// that was already used. After such an error the sequence number of the sender should be fetched from the ledger again.
+//
+// A sequence number that's too new isn't such an error, but a retryable one, see IsRetryableSubmitError(...).
+func IsSequenceNumberError(err error) bool {
+	return classifySubmitError(err) == submitErrSequenceNumber
+}
+
+// submitErrKind is the class of a SubmitError, which determines how it's handled.
+type submitErrKind int
+
+const (
+	// submitErrRejected means the transaction was rejected and the same transaction won't be accepted
+	submitErrRejected submitErrKind = iota
+	// submitErrRetryable means submitting the same transaction again can succeed
+	submitErrRetryable
+	// submitErrSequenceNumber means the sequence number was already used, so the local state is out of sync with the ledger
+	submitErrSequenceNumber
+)
+
+// classifySubmitError classifies SubmitErrors for IsRetryableSubmitError(...) and IsSequenceNumberError(...),
+// so that each error is either retried or leads to resynchronizing the sequence number, but not both.
+// Errors that aren't SubmitErrors are classified as rejected.
+func classifySubmitError(err error) submitErrKind {
+	submitErr, ok := err.(SubmitError)
+	if !ok {
+		return submitErrRejected
+	}
+	switch submitErr.MempoolStatus.GetCode() {
+	case mempool.MempoolAddTransactionStatusCode_MempoolIsFull, mempool.MempoolAddTransactionStatusCode_TooManyTransactions:
+		return submitErrRetryable
+	case mempool.MempoolAddTransactionStatusCode_InvalidSeqNumber:
+		return submitErrSequenceNumber
+	}
+	switch submitErr.ValidationStatus() {
+	// The node might not have seen the sender's previous transaction yet
+	case types.VMValidationStatusCode_SequenceNumberTooNew:
+		return submitErrRetryable
+	case types.VMValidationStatusCode_SequenceNumberTooOld:
+		return submitErrSequenceNumber
+	}
+	return submitErrRejected
+}
@@ -0,0 +1,81 @@
+package libra
+
+import (
+	"context"
+	"time"
+)
+
+// TimeFromLedgerTimestamp converts a ledger timestamp in microseconds since the Unix epoch,
+// like the TimestampUsecs of a LedgerInfo, into a time.Time.
+func TimeFromLedgerTimestamp(usecs uint64) time.Time {
+	return time.Unix(int64(usecs/1e6), int64(usecs%1e6)*1e3)
+}
+
+// LedgerTimestamp converts a time into a ledger timestamp in microseconds since the Unix epoch.
+func LedgerTimestamp(t time.Time) uint64 {
+	return uint64(t.Unix())*1e6 + uint64(t.Nanosecond())/1e3
+}
+
+// Expiration returns the expiration time of the transaction, or the zero time if it doesn't have one.
+func (rt RawTransaction) Expiration() time.Time {
+	if rt.ExpirationTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(rt.ExpirationTime), 0)
+}
+
+// SetExpiration sets the expiration time of the transaction.
+// The time is truncated to seconds, the resolution of ExpirationTime.
+// Use Client.Expiration(...) for an expiration time that's relative to the ledger's timestamp.
+func (rt *RawTransaction) SetExpiration(t time.Time) {
+	rt.ExpirationTime = uint64(t.Unix())
+}
+
+// IsExpired returns true if the transaction has an expiration time and the ledger's timestamp reached it.
+// Validator nodes reject such transactions with the VM validation status TransactionExpired.
+func (rt RawTransaction) IsExpired(ledgerTime time.Time) bool {
+	return rt.ExpirationTime != 0 && !ledgerTime.Before(rt.Expiration())
+}
+
+// LedgerTime returns the timestamp of the node's latest ledger info,
+// which is the time of the latest committed block according to the validators.
+func (c Client) LedgerTime(ctx context.Context) (time.Time, error) {
+	ledgerInfo, err := c.latestLedgerInfo(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return TimeFromLedgerTimestamp(ledgerInfo.GetTimestampUsecs()), nil
+}
+
+// Expiration returns the expiration time for a transaction that should be valid for the given TTL,
+// relative to the ledger's timestamp (see LedgerTime(...)). Use it with RawTransaction.SetExpiration(...).
+// Transactions expire when the ledger's timestamp reaches their expiration time,
+// so the local clock isn't used, which can differ from the validators' clocks.
+func (c Client) Expiration(ctx context.Context, ttl time.Duration) (time.Time, error) {
+	ledgerTime, err := c.LedgerTime(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ledgerTime.Add(ttl), nil
+}
+
+// CheckExpiration returns ErrTransactionExpired if the ledger's timestamp reached the expiration time of the transaction,
+// which would make the node reject it with the VM validation status TransactionExpired.
+// Use it before sending transactions that were signed some time ago, e.g. offline.
+func (c Client) CheckExpiration(ctx context.Context, tx Transaction) error {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return err
+	}
+	if rawTx.ExpirationTime == 0 {
+		return nil
+	}
+	ledgerTime, err := c.LedgerTime(ctx)
+	if err != nil {
+		return err
+	}
+	if rawTx.IsExpired(ledgerTime) {
+		return ErrTransactionExpired
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package libra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestLedgerTimestamp tests the conversion between ledger timestamps and time.Time.
+func TestLedgerTimestamp(t *testing.T) {
+	var usecs uint64 = 1563000000123456
+	ledgerTime := libra.TimeFromLedgerTimestamp(usecs)
+	if !ledgerTime.Equal(time.Unix(1563000000, 123456000)) {
+		t.Fatalf("Expected %v, but was %v", time.Unix(1563000000, 123456000), ledgerTime)
+	}
+	if libra.LedgerTimestamp(ledgerTime) != usecs {
+		t.Fatalf("Expected %v, but was %v", usecs, libra.LedgerTimestamp(ledgerTime))
+	}
+	if libra.LedgerTimestamp(time.Unix(1, 999)) != 1000000 {
+		t.Fatal("Expected the nanoseconds to be truncated")
+	}
+}
+
+// TestRawTransactionExpiration tests setting the expiration time of a raw transaction and checking if it expired.
+func TestRawTransactionExpiration(t *testing.T) {
+	var rawTx libra.RawTransaction
+	if !rawTx.Expiration().IsZero() || rawTx.IsExpired(time.Now()) {
+		t.Fatal("Expected a transaction without expiration time to not expire")
+	}
+
+	expiration := time.Unix(1563000000, 0)
+	rawTx.SetExpiration(expiration.Add(500 * time.Millisecond))
+	if rawTx.ExpirationTime != 1563000000 || !rawTx.Expiration().Equal(expiration) {
+		t.Fatalf("Expected the expiration time %v, but was %v", expiration, rawTx.Expiration())
+	}
+	if rawTx.IsExpired(expiration.Add(-time.Millisecond)) {
+		t.Fatal("Expected the transaction to not be expired before its expiration time")
+	}
+	if !rawTx.IsExpired(expiration) {
+		t.Fatal("Expected the transaction to be expired at its expiration time")
+	}
+}
+
+// TestClientExpiration tests if expiration times are relative to the ledger's timestamp instead of the local clock,
+// with a ledger whose timestamp is an hour ahead.
+func TestClientExpiration(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptCreateAccount,
+		Code: []byte{10, 11, 12},
+	})
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ledgerTime := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	s.Clock = func() time.Time {
+		return ledgerTime
+	}
+	s.SetAccount(sender, libra.AccountResource{Balance: 10 * libra.Libra, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	ctx := context.Background()
+
+	actualLedgerTime, err := c.LedgerTime(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !actualLedgerTime.Equal(ledgerTime) {
+		t.Fatalf("Expected the ledger time %v, but was %v", ledgerTime, actualLedgerTime)
+	}
+	expiration, err := c.Expiration(ctx, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expiration.Equal(ledgerTime.Add(time.Minute)) {
+		t.Fatalf("Expected the expiration time %v, but was %v", ledgerTime.Add(time.Minute), expiration)
+	}
+
+	// With the local clock, the transaction would be expired on arrival
+	seqNo, err := c.CreateAccount(ctx, signer, libra.AccountAddress{5}, libra.Libra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := c.GetAccountTransaction(ctx, sender, seqNo, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx, err := libra.RawTransactionFromBytes(tx.Transaction.RawBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rawTx.Expiration().Equal(ledgerTime.Add(libra.DefaultTransactionTTL).Truncate(time.Second)) {
+		t.Fatalf("Expected the expiration time %v, but was %v", ledgerTime.Add(libra.DefaultTransactionTTL), rawTx.Expiration())
+	}
+
+	// A transaction that's still valid according to the local clock is detected as expired
+	rawTx.SequenceNo++
+	rawTx.SetExpiration(time.Now().Add(time.Minute))
+	expiredTx, err := rawTx.Sign(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CheckExpiration(ctx, expiredTx); err != libra.ErrTransactionExpired {
+		t.Fatalf("Expected %v, but was %v", libra.ErrTransactionExpired, err)
+	}
+	err = c.SendTx(expiredTx)
+	if submitErr, ok := err.(libra.SubmitError); !ok || submitErr.VMStatus.GetValidation().GetCode() != types.VMValidationStatusCode_TransactionExpired {
+		t.Fatalf("Expected the node to reject the transaction with TransactionExpired, but was %v", err)
+	}
+
+	rawTx.SetExpiration(ledgerTime.Add(time.Minute))
+	validTx, err := rawTx.Sign(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CheckExpiration(ctx, validTx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,17 @@
+package libra
+
+// ResetScripts unregisters all scripts, so that a test doesn't depend on the scripts that other tests registered.
+// It returns a function that restores the previously registered scripts, so that other tests don't depend on it:
+//
+//	defer libra.ResetScripts()()
+func ResetScripts() (restore func()) {
+	scriptsLock.Lock()
+	defer scriptsLock.Unlock()
+	previous := scripts
+	scripts = map[string]Script{}
+	return func() {
+		scriptsLock.Lock()
+		defer scriptsLock.Unlock()
+		scripts = previous
+	}
+}
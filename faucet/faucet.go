@@ -0,0 +1,85 @@
+// Package faucet implements a client for Libra's faucet, which mints Libra Coins on the testnet.
+//
+// For tests without network access, libratest.Server.FaucetHandler() provides a stand-in faucet.
+package faucet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// DefaultURL is the URL of the testnet's faucet.
+const DefaultURL = "http://faucet.testnet.libra.org"
+
+// DefaultTimeout is the timeout of requests to the faucet, unless Client.HTTPClient is set.
+const DefaultTimeout = 30 * time.Second
+
+// maxResponseSize limits the size of the response bodies that are read.
+const maxResponseSize = 1 << 16
+
+// Client is a client for Libra's faucet.
+type Client struct {
+	// URL of the faucet, e.g. DefaultURL
+	URL string
+	// HTTPClient is used for the requests. If nil, a client with DefaultTimeout is used.
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client for the faucet with the given URL.
+func NewClient(faucetURL string) Client {
+	return Client{
+		URL: faucetURL,
+	}
+}
+
+// Mint requests the given amount of Libra Coins for the account with the given address.
+// The faucet creates the account if it doesn't exist.
+//
+// The faucet mints with a transaction of the association account (libra.AssociationAddress)
+// and responds with the sequence number of the association account after that transaction.
+// It's returned, so the mint transaction can be waited for with
+// libra.Client.WaitForTransaction(ctx, libra.AssociationAddress, seqNo-1).
+func (c Client) Mint(addr libra.AccountAddress, amount libra.Amount) (uint64, error) {
+	if amount == 0 {
+		return 0, errors.New("The amount must be greater than 0")
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return 0, err
+	}
+	query := u.Query()
+	query.Set("amount", strconv.FormatUint(amount.MicroLibra(), 10))
+	query.Set("address", addr.String())
+	u.RawQuery = query.Encode()
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	res, err := httpClient.Post(u.String(), "", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return 0, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return 0, fmt.Errorf("The faucet responded with status %v: %v", res.Status, strings.TrimSpace(string(body)))
+	}
+	seqNo, err := strconv.ParseUint(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid response of the faucet: %v", err)
+	}
+	return seqNo, nil
+}
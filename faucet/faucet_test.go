@@ -0,0 +1,93 @@
+package faucet_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/faucet"
+	"github.com/philippgille/libra-sdk-go/libratest"
+)
+
+func init() {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptMint,
+		Code: []byte{13, 14, 15},
+	})
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptPeerToPeerTransfer,
+		Code: []byte{1, 2, 3},
+	})
+}
+
+// TestMint tests if the faucet client mints with the stand-in faucet
+// and if the minted coins can be transferred afterwards.
+func TestMint(t *testing.T) {
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	hs := httptest.NewServer(s.FaucetHandler())
+	defer hs.Close()
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	sender, receiver := libra.AccountAddress{1}, libra.AccountAddress{2}
+	fc := faucet.NewClient(hs.URL)
+	for i, amount := range []libra.Amount{10 * libra.Libra, 5 * libra.Libra} {
+		seqNo, err := fc.Mint(sender, amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seqNo != uint64(i+1) {
+			t.Fatalf("Expected sequence number %v, but was %v", i+1, seqNo)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		res, err := c.WaitForTransaction(ctx, libra.AssociationAddress, seqNo-1)
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Events) != 1 {
+			t.Fatalf("Expected a received payment event, but got %v events", len(res.Events))
+		}
+	}
+
+	rawTx, err := libra.NewTransferTransaction(sender, 0, receiver, 12*libra.Libra, libra.DefaultFeePolicy.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SendTx(libra.Transaction{RawBytes: rawTxBytes}); err != nil {
+		t.Fatal(err)
+	}
+
+	for addr, expected := range map[libra.AccountAddress]libra.Amount{sender: 3 * libra.Libra, receiver: 12 * libra.Libra} {
+		accState, err := c.GetAccountState(addr.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if accState.AccountResource.Balance != expected {
+			t.Fatalf("Expected a balance of %v LBR, but was %v LBR", expected, accState.AccountResource.Balance)
+		}
+	}
+}
+
+// TestMintError tests if error responses of the faucet lead to an error.
+func TestMintError(t *testing.T) {
+	hs := httptest.NewServer(http.NotFoundHandler())
+	defer hs.Close()
+	if _, err := faucet.NewClient(hs.URL).Mint(libra.AccountAddress{1}, libra.Libra); err == nil {
+		t.Fatal("Expected an error")
+	}
+}
@@ -0,0 +1,245 @@
+package libra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// Gas bounds of the Libra VM.
+// Transactions with values outside of these bounds are rejected.
+const (
+	// MinTransactionGasUnits is the minimum max gas amount of a transaction, which covers its intrinsic cost.
+	MinTransactionGasUnits uint64 = 600
+	// MaxTransactionGasUnits is the maximum max gas amount of a transaction.
+	MaxTransactionGasUnits uint64 = 1000000
+	// MaxGasUnitPrice is the maximum gas unit price.
+	MaxGasUnitPrice Amount = 10000
+	// LargeTransactionCutoff is the size in bytes of a raw transaction up to which its intrinsic cost is MinTransactionGasUnits.
+	LargeTransactionCutoff = 600
+	// IntrinsicGasPerByte is the gas that each byte of a raw transaction above LargeTransactionCutoff adds to its intrinsic cost.
+	IntrinsicGasPerByte uint64 = 8
+)
+
+// IntrinsicGas returns the intrinsic cost of a transaction, which depends on the size of its raw transaction in bytes.
+// The VM rejects transactions whose max gas amount doesn't cover it with the validation status
+// MaxGasUnitsBelowMinTransactionGasUnits.
+func IntrinsicGas(rawTxSize int) uint64 {
+	if rawTxSize <= LargeTransactionCutoff {
+		return MinTransactionGasUnits
+	}
+	return MinTransactionGasUnits + uint64(rawTxSize-LargeTransactionCutoff)*IntrinsicGasPerByte
+}
+
+// DefaultFeePolicy uses the same gas values as the Libra CLI
+// and estimates the gas amount with a margin of 50%.
+var DefaultFeePolicy = FeePolicy{
+	Default: Fee{
+		MaxGasAmount: 140000,
+		GasUnitPrice: 0,
+	},
+	EstimationWindow: 100,
+	MarginPercent:    50,
+}
+
+// Fee contains the gas fields of a transaction.
+type Fee struct {
+	// MaxGasAmount is the maximum number of gas units the sender is willing to spend for a transaction
+	MaxGasAmount uint64
+	// GasUnitPrice is the price to be paid for each gas unit
+	GasUnitPrice Amount
+}
+
+// Max returns the maximum fee of a transaction, which is the max gas amount multiplied by the gas unit price.
+// The sender's balance must cover it.
+func (f Fee) Max() (Amount, error) {
+	return f.GasUnitPrice.Mul(f.MaxGasAmount)
+}
+
+// Validate checks if the fee is within the bounds of the Libra VM.
+// If not, a FeeError with the VM validation status that the VM would return is returned.
+func (f Fee) Validate() error {
+	var code types.VMValidationStatusCode
+	switch {
+	case f.MaxGasAmount < MinTransactionGasUnits:
+		code = types.VMValidationStatusCode_MaxGasUnitsBelowMinTransactionGasUnits
+	case f.MaxGasAmount > MaxTransactionGasUnits:
+		code = types.VMValidationStatusCode_MaxGasUnitsExceedsMaxGasUnitsBound
+	case f.GasUnitPrice > MaxGasUnitPrice:
+		code = types.VMValidationStatusCode_GasUnitPriceAboveMaxBound
+	default:
+		return nil
+	}
+	return FeeError{
+		Code: code,
+		Fee:  f,
+	}
+}
+
+// FeeError is returned when a fee is outside of the bounds of the Libra VM.
+type FeeError struct {
+	// Code is the VM validation status that the VM would return for the fee
+	Code types.VMValidationStatusCode
+	Fee  Fee
+}
+
+// Error implements the error interface.
+func (e FeeError) Error() string {
+	return fmt.Sprintf("Invalid fee (max gas amount: %v, gas unit price: %v): %v", e.Fee.MaxGasAmount, e.Fee.GasUnitPrice.MicroLibra(), e.Code)
+}
+
+// InsufficientBalanceError is returned when an account's balance doesn't cover the max fee (and amount) of a transaction.
+type InsufficientBalanceError struct {
+	Balance  Amount
+	Required Amount
+}
+
+// Error implements the error interface.
+func (e InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("Insufficient balance: %v LBR available, but %v LBR required", e.Balance, e.Required)
+}
+
+// FeePolicy determines the gas fields of transactions.
+type FeePolicy struct {
+	// Default is used when no estimation is possible.
+	// Its gas unit price is also used for estimated fees.
+	Default Fee
+	// EstimationWindow is the number of most recent transactions on the ledger
+	// that are looked at for estimating the gas amount.
+	EstimationWindow uint64
+	// MarginPercent is added to the estimated gas amount, e.g. 50 for 1.5 times the estimation.
+	MarginPercent uint64
+}
+
+// WithFeePolicy returns a copy of the client whose transaction helpers, like CreateAccount(...),
+// RotateAuthenticationKey(...) and PublishModules(...), estimate fees with the given policy instead of DefaultFeePolicy.
+func (c Client) WithFeePolicy(policy FeePolicy) Client {
+	c.feePolicy = &policy
+	return c
+}
+
+// WithFee returns a copy of the client whose transaction helpers use the given fee instead of estimating it,
+// e.g. for overriding the fee of a single transaction with c.WithFee(fee).CreateAccount(...).
+// The fee is validated before the transaction is signed, see Fee.Validate().
+func (c Client) WithFee(fee Fee) Client {
+	c.fee = &fee
+	return c
+}
+
+// transactionFee returns the fee of a transaction with the given script that's created by a transaction helper.
+// It's the fee that's set via WithFee(...), or the fee that's estimated with the client's fee policy.
+func (c Client) transactionFee(ctx context.Context, code []byte) (Fee, error) {
+	if c.fee != nil {
+		return *c.fee, nil
+	}
+	return c.EstimateFee(ctx, code, c.policy())
+}
+
+// policy returns the fee policy that's set via WithFeePolicy(...), or DefaultFeePolicy.
+func (c Client) policy() FeePolicy {
+	if c.feePolicy != nil {
+		return *c.feePolicy
+	}
+	return DefaultFeePolicy
+}
+
+// EstimateFee estimates the fee of a transaction with the given script.
+// It looks at the gas used by the most recent transactions with the same script on the ledger
+// and uses the maximum plus the policy's margin as max gas amount, bounded by the limits of the Libra VM.
+// If there are no such transactions, the policy's default fee is returned.
+func (c Client) EstimateFee(ctx context.Context, code []byte, policy FeePolicy) (Fee, error) {
+	gasUsed, found, err := c.recentGasUsed(ctx, code, policy.EstimationWindow)
+	if err != nil {
+		return Fee{}, err
+	}
+	if !found {
+		return policy.Default, nil
+	}
+	maxGasAmount := gasUsed + gasUsed*policy.MarginPercent/100
+	if maxGasAmount < MinTransactionGasUnits {
+		maxGasAmount = MinTransactionGasUnits
+	} else if maxGasAmount > MaxTransactionGasUnits {
+		maxGasAmount = MaxTransactionGasUnits
+	}
+	return Fee{
+		MaxGasAmount: maxGasAmount,
+		GasUnitPrice: policy.Default.GasUnitPrice,
+	}, nil
+}
+
+// recentGasUsed returns the max gas used by the transactions with the given script
+// within the given number of most recent transactions on the ledger.
+func (c Client) recentGasUsed(ctx context.Context, code []byte, window uint64) (uint64, bool, error) {
+	ledgerInfo, err := c.latestLedgerInfo(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	version := ledgerInfo.GetVersion()
+	start := uint64(0)
+	if version+1 > window {
+		start = version + 1 - window
+	}
+	requestItem := &types.RequestItem{
+		RequestedItems: &types.RequestItem_GetTransactionsRequest{
+			GetTransactionsRequest: &types.GetTransactionsRequest{
+				StartVersion: start,
+				Limit:        window,
+			},
+		},
+	}
+	responseItem, _, err := c.requestItem(ctx, requestItem)
+	if err != nil {
+		return 0, false, err
+	}
+	txList := responseItem.GetGetTransactionsResponse().GetTxnListWithProof()
+	infos := txList.GetInfos()
+
+	var maxGasUsed uint64
+	found := false
+	for i, signedTx := range txList.GetTransactions() {
+		if i >= len(infos) {
+			break
+		}
+		rawTx := types.RawTransaction{}
+		if err := proto.Unmarshal(signedTx.GetRawTxnBytes(), &rawTx); err != nil {
+			continue
+		}
+		if !bytes.Equal(rawTx.GetProgram().GetCode(), code) {
+			continue
+		}
+		found = true
+		if gasUsed := infos[i].GetGasUsed(); gasUsed > maxGasUsed {
+			maxGasUsed = gasUsed
+		}
+	}
+	return maxGasUsed, found, nil
+}
+
+// CheckBalance checks if the balance of the sender covers the max fee of a transaction plus the given amount,
+// e.g. the amount of a transfer.
+// If not, an InsufficientBalanceError is returned.
+func (c Client) CheckBalance(ctx context.Context, sender AccountAddress, fee Fee, amount Amount) error {
+	maxFee, err := fee.Max()
+	if err != nil {
+		return err
+	}
+	required, err := maxFee.Add(amount)
+	if err != nil {
+		return err
+	}
+	accState, err := c.GetAccountStateContext(ctx, sender.String())
+	if err != nil {
+		return err
+	}
+	if balance := accState.AccountResource.Balance; balance < required {
+		return InsufficientBalanceError{
+			Balance:  balance,
+			Required: required,
+		}
+	}
+	return nil
+}
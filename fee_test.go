@@ -0,0 +1,165 @@
+package libra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestFeeValidate tests if libra.Fee.Validate() detects fees outside of the bounds of the Libra VM.
+func TestFeeValidate(t *testing.T) {
+	if err := libra.DefaultFeePolicy.Default.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[libra.Fee]types.VMValidationStatusCode{
+		{MaxGasAmount: 599}:                          types.VMValidationStatusCode_MaxGasUnitsBelowMinTransactionGasUnits,
+		{MaxGasAmount: 1000001}:                      types.VMValidationStatusCode_MaxGasUnitsExceedsMaxGasUnitsBound,
+		{MaxGasAmount: 1000, GasUnitPrice: 10000001}: types.VMValidationStatusCode_GasUnitPriceAboveMaxBound,
+	}
+	for fee, expected := range tests {
+		err := fee.Validate()
+		feeErr, ok := err.(libra.FeeError)
+		if !ok {
+			t.Fatalf("Expected a libra.FeeError, but was %v", err)
+		}
+		if feeErr.Code != expected {
+			t.Fatalf("Expected %v, but was %v", expected, feeErr.Code)
+		}
+	}
+}
+
+// TestEstimateFee tests if libra.Client.EstimateFee(...) uses the gas of recent transactions with the same script.
+func TestEstimateFee(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+	s.GasUsed = 1000
+	// Registered scripts with the same code would require arguments,
+	// also in the libratest server, which checks the arguments of registered scripts
+	defer libra.ResetScripts()()
+
+	code := []byte{1, 2, 3}
+	rawTx := libra.RawTransaction{
+		Sender:  addr,
+		Program: &libra.Program{Code: code},
+	}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SendTx(libra.Transaction{RawBytes: rawTxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fee, err := c.EstimateFee(context.Background(), code, libra.DefaultFeePolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee.MaxGasAmount != 1500 {
+		t.Fatalf("Expected max gas amount 1500, but was %v", fee.MaxGasAmount)
+	}
+
+	// Without transactions of the same script, the default is used
+	fee, err = c.EstimateFee(context.Background(), []byte{4, 5, 6}, libra.DefaultFeePolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != libra.DefaultFeePolicy.Default {
+		t.Fatalf("Expected %v, but was %v", libra.DefaultFeePolicy.Default, fee)
+	}
+}
+
+// TestCheckBalance tests if libra.Client.CheckBalance(...) detects when the balance doesn't cover the max fee and amount.
+func TestCheckBalance(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{Balance: libra.Libra})
+	defer s.Close()
+	defer c.Close()
+
+	fee := libra.Fee{MaxGasAmount: 1000, GasUnitPrice: 100}
+	err := c.CheckBalance(context.Background(), addr, fee, 900000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.CheckBalance(context.Background(), addr, fee, 900001)
+	balanceErr, ok := err.(libra.InsufficientBalanceError)
+	if !ok {
+		t.Fatalf("Expected a libra.InsufficientBalanceError, but was %v", err)
+	}
+	if balanceErr.Required != 1000001 {
+		t.Fatalf("Expected 1000001 required, but was %v", balanceErr.Required.MicroLibra())
+	}
+}
+
+// TestClientFee tests if the transaction helpers use the fee policy and fee of libra.Client.WithFeePolicy(...)
+// and libra.Client.WithFee(...), and reject fees outside of the bounds of the Libra VM before sending.
+func TestClientFee(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptCreateAccount,
+		Code: []byte{10, 11, 12},
+	})
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.SetAccount(sender, libra.AccountResource{Balance: 10 * libra.Libra, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s.GasUsed = 1000
+
+	// The first transaction gets the default fee, the second one an estimation from the first one's gas
+	policy := libra.DefaultFeePolicy
+	policy.Default.GasUnitPrice = 1
+	policy.MarginPercent = 100
+	fixedFee := libra.Fee{MaxGasAmount: 3000, GasUnitPrice: 2}
+	testCases := []struct {
+		c        libra.Client
+		expected libra.Fee
+	}{
+		{c, libra.DefaultFeePolicy.Default},
+		{c.WithFeePolicy(policy), libra.Fee{MaxGasAmount: 2000, GasUnitPrice: 1}},
+		{c.WithFeePolicy(policy).WithFee(fixedFee), fixedFee},
+	}
+	for i, tc := range testCases {
+		seqNo, err := tc.c.CreateAccount(context.Background(), signer, libra.AccountAddress{byte(100 + i)}, libra.Libra)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := c.GetAccountTransaction(context.Background(), sender, seqNo, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rawTx, err := libra.RawTransactionFromBytes(tx.Transaction.RawBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee := (libra.Fee{MaxGasAmount: rawTx.MaxGasAmount, GasUnitPrice: rawTx.GasUnitPrice}); fee != tc.expected {
+			t.Fatalf("Expected the fee %+v, but was %+v", tc.expected, fee)
+		}
+	}
+
+	_, err = c.WithFee(libra.Fee{MaxGasAmount: 100}).CreateAccount(context.Background(), signer, libra.AccountAddress{99}, libra.Libra)
+	if feeErr, ok := err.(libra.FeeError); !ok || feeErr.Code != types.VMValidationStatusCode_MaxGasUnitsBelowMinTransactionGasUnits {
+		t.Fatalf("Expected a libra.FeeError with MaxGasUnitsBelowMinTransactionGasUnits, but was %v", err)
+	}
+	if accRes, _ := s.Account(sender); accRes.SequenceNo != uint64(len(testCases)) {
+		t.Fatalf("Expected the invalid transaction not to be sent, but the sequence number was %v", accRes.SequenceNo)
+	}
+}
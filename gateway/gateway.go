@@ -0,0 +1,311 @@
+// Package gateway implements a JSON/HTTP gateway for Libra's AdmissionControl gRPC service,
+// for clients that can't use gRPC.
+//
+// Endpoints:
+//
+//	GET  /accounts/{address}                               Account state
+//	GET  /accounts/{address}/transactions/{sequence no}    Committed transaction of an account, with ?events=true for its events
+//	GET  /accounts/{address}/events/sent                   Sent payment events, with ?start=, ?limit= and ?order=asc|desc
+//	GET  /accounts/{address}/events/received               Received payment events, with the same parameters
+//	GET  /transactions                                     Committed transactions, with ?start=, ?limit= and ?events=true
+//	POST /transactions                                     Submit a signed transaction
+//
+// The request and response bodies use the JSON encodings of the SDK's types,
+// e.g. libra.AccountState, libra.CommittedTransaction, libra.Event and libra.Transaction.
+// Errors are returned as {"error":"..."} with an HTTP status code that matches the error.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// Limits of the number of transactions and events per request
+const (
+	DefaultLimit = 10
+	MaxLimit     = 1000
+)
+
+// DefaultTimeout is the timeout for the requests to the node, unless Gateway.Timeout is set.
+const DefaultTimeout = 10 * time.Second
+
+// maxBodySize limits the size of request bodies
+const maxBodySize = 1 << 20
+
+// Gateway is an http.Handler that serves the REST endpoints.
+type Gateway struct {
+	// Timeout for the requests to the node. If 0, DefaultTimeout is used.
+	Timeout time.Duration
+
+	c libra.Client
+}
+
+// New creates a gateway that uses the given client for the requests to the node.
+func New(c libra.Client) *Gateway {
+	return &Gateway{
+		c: c,
+	}
+}
+
+// httpError is an error with an HTTP status code.
+type httpError struct {
+	code int
+	err  error
+}
+
+func (e httpError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, a ...interface{}) error {
+	return httpError{code: http.StatusBadRequest, err: fmt.Errorf(format, a...)}
+}
+
+// submitResponse is the response of POST /transactions.
+type submitResponse struct {
+	Sender     libra.AccountAddress `json:"sender"`
+	SequenceNo uint64               `json:"sequence_number,string"`
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	timeout := g.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	result, code, err := g.route(ctx, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, code, result)
+}
+
+// route calls the handler of the request's endpoint and returns its result with the HTTP status code for it.
+func (g *Gateway) route(ctx context.Context, r *http.Request) (interface{}, int, error) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+	switch {
+	case len(path) == 1 && path[0] == "transactions":
+		switch r.Method {
+		case http.MethodGet:
+			result, err := g.getTransactions(ctx, query)
+			return result, http.StatusOK, err
+		case http.MethodPost:
+			result, err := g.submitTransaction(ctx, r.Body)
+			return result, http.StatusAccepted, err
+		}
+	case len(path) >= 2 && path[0] == "accounts" && r.Method == http.MethodGet:
+		addr, err := libra.ParseAccountAddress(path[1])
+		if err != nil {
+			return nil, 0, badRequest("Invalid account address: %v", err)
+		}
+		switch {
+		case len(path) == 2:
+			result, err := g.getAccount(ctx, addr)
+			return result, http.StatusOK, err
+		case len(path) == 4 && path[2] == "transactions":
+			result, err := g.getAccountTransaction(ctx, addr, path[3], query)
+			return result, http.StatusOK, err
+		case len(path) == 4 && path[2] == "events" && path[3] == "sent":
+			result, err := g.getEvents(ctx, accesspath.SentEvents(addr.Bytes()), query)
+			return result, http.StatusOK, err
+		case len(path) == 4 && path[2] == "events" && path[3] == "received":
+			result, err := g.getEvents(ctx, accesspath.ReceivedEvents(addr.Bytes()), query)
+			return result, http.StatusOK, err
+		}
+	}
+	return nil, 0, httpError{code: http.StatusNotFound, err: fmt.Errorf("Unknown endpoint: %v %v", r.Method, r.URL.Path)}
+}
+
+func (g *Gateway) getAccount(ctx context.Context, addr libra.AccountAddress) (libra.AccountState, error) {
+	return g.c.GetAccountStateContext(ctx, addr.String())
+}
+
+func (g *Gateway) getAccountTransaction(ctx context.Context, addr libra.AccountAddress, seqNoParam string, query map[string][]string) (libra.CommittedTransaction, error) {
+	seqNo, err := strconv.ParseUint(seqNoParam, 10, 64)
+	if err != nil {
+		return libra.CommittedTransaction{}, badRequest("Invalid sequence number: %v", err)
+	}
+	fetchEvents, err := boolParam(query, "events")
+	if err != nil {
+		return libra.CommittedTransaction{}, err
+	}
+	return g.c.GetAccountTransaction(ctx, addr, seqNo, fetchEvents)
+}
+
+func (g *Gateway) getTransactions(ctx context.Context, query map[string][]string) ([]libra.CommittedTransaction, error) {
+	start, err := uintParam(query, "start", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := limitParam(query)
+	if err != nil {
+		return nil, err
+	}
+	fetchEvents, err := boolParam(query, "events")
+	if err != nil {
+		return nil, err
+	}
+	txs, err := g.c.GetTransactions(ctx, start, limit, fetchEvents)
+	if txs == nil {
+		txs = []libra.CommittedTransaction{}
+	}
+	return txs, err
+}
+
+func (g *Gateway) getEvents(ctx context.Context, eventHandle *types.AccessPath, query map[string][]string) ([]libra.Event, error) {
+	ascending := true
+	switch order := firstParam(query, "order"); order {
+	case "", "asc":
+	case "desc":
+		ascending = false
+	default:
+		return nil, badRequest("Invalid order: %q", order)
+	}
+	defaultStart := uint64(0)
+	if !ascending {
+		// The maximum sequence number represents the latest event
+		defaultStart = math.MaxUint64
+	}
+	start, err := uintParam(query, "start", defaultStart)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := limitParam(query)
+	if err != nil {
+		return nil, err
+	}
+	events, err := g.c.GetEvents(ctx, eventHandle, start, ascending, limit)
+	if events == nil {
+		events = []libra.Event{}
+	}
+	return events, err
+}
+
+func (g *Gateway) submitTransaction(ctx context.Context, body io.Reader) (submitResponse, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxBodySize))
+	if err != nil {
+		return submitResponse{}, err
+	}
+	var tx libra.Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return submitResponse{}, badRequest("Invalid transaction: %v", err)
+	}
+	rawTx, err := libra.RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return submitResponse{}, badRequest("Invalid raw transaction: %v", err)
+	}
+	if err := g.c.SendTxContext(ctx, tx); err != nil {
+		return submitResponse{}, err
+	}
+	return submitResponse{
+		Sender:     rawTx.Sender,
+		SequenceNo: rawTx.SequenceNo,
+	}, nil
+}
+
+func firstParam(query map[string][]string, name string) string {
+	if values := query[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func uintParam(query map[string][]string, name string, defaultValue uint64) (uint64, error) {
+	param := firstParam(query, name)
+	if param == "" {
+		return defaultValue, nil
+	}
+	v, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, badRequest("Invalid %v: %v", name, err)
+	}
+	return v, nil
+}
+
+func boolParam(query map[string][]string, name string) (bool, error) {
+	param := firstParam(query, name)
+	if param == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, badRequest("Invalid %v: %v", name, err)
+	}
+	return v, nil
+}
+
+func limitParam(query map[string][]string) (uint64, error) {
+	limit, err := uintParam(query, "limit", DefaultLimit)
+	if err != nil {
+		return 0, err
+	}
+	if limit > MaxLimit {
+		return 0, badRequest("The limit must not be greater than %v", MaxLimit)
+	}
+	return limit, nil
+}
+
+// statusCode returns the HTTP status code for an error.
+func statusCode(err error) int {
+	if httpErr, ok := err.(httpError); ok {
+		return httpErr.code
+	}
+	switch err {
+	case libra.ErrAccountNotFound, libra.ErrTransactionNotFound:
+		return http.StatusNotFound
+	case context.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	switch err.(type) {
+	case libra.SubmitError:
+		return http.StatusUnprocessableEntity
+	}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unknown:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// errorResponse is the response body of failed requests.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusCode(err), errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	// Errors can't be reported anymore after the header was written
+	_ = json.NewEncoder(w).Encode(v)
+}
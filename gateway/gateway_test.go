@@ -0,0 +1,205 @@
+package gateway_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/gateway"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/admission_control"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+func newTestGateway(t *testing.T) (*libratest.Server, libra.Client, *httptest.Server) {
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		s.Close()
+		t.Fatal(err)
+	}
+	return s, c, httptest.NewServer(gateway.New(c))
+}
+
+// request sends a request to the gateway, checks the status code and decodes the JSON response into v.
+func request(t *testing.T, method, url string, body []byte, expectedCode int, v interface{}) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != expectedCode {
+		t.Fatalf("%v %v: Expected status code %v, but was %v", method, url, expectedCode, res.StatusCode)
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestGateway tests if transactions can be submitted and queried via the gateway.
+func TestGateway(t *testing.T) {
+	s, c, hs := newTestGateway(t)
+	defer s.Close()
+	defer c.Close()
+	defer hs.Close()
+	sender := libra.AccountAddress{1}
+	s.SetAccount(sender, libra.AccountResource{Balance: 42, AuthKey: sender.Bytes()})
+
+	var accState libra.AccountState
+	request(t, http.MethodGet, hs.URL+"/accounts/"+sender.String(), nil, http.StatusOK, &accState)
+	if accState.AccountResource.Balance != 42 {
+		t.Fatalf("Expected balance 42, but was %v", accState.AccountResource.Balance)
+	}
+
+	rawTx := libra.RawTransaction{
+		Sender:     sender,
+		SequenceNo: 0,
+		Program:    &libra.Program{Code: []byte{4, 5, 6}},
+	}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(libra.Transaction{RawBytes: rawTxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var submitRes map[string]string
+	request(t, http.MethodPost, hs.URL+"/transactions", body, http.StatusAccepted, &submitRes)
+	if submitRes["sequence_number"] != "0" {
+		t.Fatalf("Expected sequence number 0, but was %v", submitRes["sequence_number"])
+	}
+
+	var committedTx libra.CommittedTransaction
+	request(t, http.MethodGet, hs.URL+"/accounts/"+sender.String()+"/transactions/0?events=true", nil, http.StatusOK, &committedTx)
+	if !bytes.Equal(committedTx.Transaction.RawBytes, rawTxBytes) {
+		t.Fatal("The returned transaction doesn't match the submitted one")
+	}
+
+	var txs []libra.CommittedTransaction
+	request(t, http.MethodGet, hs.URL+"/transactions?start=0&limit=5", nil, http.StatusOK, &txs)
+	if len(txs) != 1 || txs[0].Version != committedTx.Version {
+		t.Fatalf("Expected the submitted transaction, but got %v transactions", len(txs))
+	}
+
+	var events []libra.Event
+	request(t, http.MethodGet, hs.URL+"/accounts/"+sender.String()+"/events/sent?order=desc", nil, http.StatusOK, &events)
+	if len(events) != 0 {
+		t.Fatalf("Expected no events, but got %v", len(events))
+	}
+
+	// Resubmitting the same transaction fails because of its sequence number
+	var errRes map[string]string
+	request(t, http.MethodPost, hs.URL+"/transactions", body, http.StatusUnprocessableEntity, &errRes)
+	if errRes["error"] == "" {
+		t.Fatal("Expected an error message")
+	}
+}
+
+// TestGatewayErrors tests the status codes of invalid requests.
+func TestGatewayErrors(t *testing.T) {
+	s, c, hs := newTestGateway(t)
+	defer s.Close()
+	defer c.Close()
+	defer hs.Close()
+
+	testCases := []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/accounts/" + libra.AccountAddress{3}.String(), http.StatusNotFound},
+		{http.MethodGet, "/accounts/xyz", http.StatusBadRequest},
+		{http.MethodGet, "/accounts/" + libra.AccountAddress{3}.String() + "/transactions/0", http.StatusNotFound},
+		{http.MethodGet, "/accounts/" + libra.AccountAddress{3}.String() + "/transactions/x", http.StatusBadRequest},
+		{http.MethodGet, "/transactions?limit=100000", http.StatusBadRequest},
+		{http.MethodPost, "/transactions", http.StatusBadRequest},
+		{http.MethodGet, "/unknown", http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		var errRes map[string]string
+		request(t, tc.method, hs.URL+tc.path, []byte("{"), tc.code, &errRes)
+		if errRes["error"] == "" {
+			t.Fatalf("%v %v: Expected an error message", tc.method, tc.path)
+		}
+	}
+}
+
+// stuckServer is a node that doesn't respond until the request's context is done.
+type stuckServer struct {
+	*libratest.Server
+}
+
+// UpdateToLatestLedger implements admission_control.AdmissionControlServer.
+func (stuckServer) UpdateToLatestLedger(ctx context.Context, req *types.UpdateToLatestLedgerRequest) (*types.UpdateToLatestLedgerResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// SubmitTransaction implements admission_control.AdmissionControlServer.
+func (stuckServer) SubmitTransaction(ctx context.Context, req *admission_control.SubmitTransactionRequest) (*admission_control.SubmitTransactionResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestGatewayTimeout tests if the requests of all endpoints to a stuck node are canceled after Gateway.Timeout.
+func TestGatewayTimeout(t *testing.T) {
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	admission_control.RegisterAdmissionControlServer(grpcServer, stuckServer{Server: s})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+	c, err := libra.NewClient(lis.Addr().String(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	g := gateway.New(c)
+	g.Timeout = 100 * time.Millisecond
+	hs := httptest.NewServer(g)
+	defer hs.Close()
+
+	rawTxBytes, err := libra.RawTransaction{Sender: libra.AccountAddress{1}}.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	txJSON, err := json.Marshal(libra.Transaction{RawBytes: rawTxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		method string
+		path   string
+		body   []byte
+	}{
+		{http.MethodGet, "/accounts/" + libra.AccountAddress{1}.String(), nil},
+		{http.MethodPost, "/transactions", txJSON},
+	}
+	for _, tc := range testCases {
+		var errRes map[string]string
+		request(t, tc.method, hs.URL+tc.path, tc.body, http.StatusGatewayTimeout, &errRes)
+	}
+}
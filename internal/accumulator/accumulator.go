@@ -0,0 +1,151 @@
+// Package accumulator implements Libra's Merkle accumulators, which are used for the transactions of the ledger
+// and the events of a transaction.
+//
+// The leaves are padded with placeholder hashes to the next power of two. Parent nodes are the salted hash
+// of their concatenated children, except for subtrees that only contain placeholders, which are placeholders themselves.
+// Proofs contain the siblings of all nodes on the path from a leaf to the root.
+package accumulator
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// PlaceholderHash is the hash of empty subtrees and the root hash of an empty accumulator.
+// Like in Libra it's the literal "ACCUMULATOR_PLACEHOLDER_HASH", padded with zeros to 32 bytes.
+var PlaceholderHash = func() []byte {
+	b := make([]byte, 32)
+	copy(b, "ACCUMULATOR_PLACEHOLDER_HASH")
+	return b
+}()
+
+// ErrInvalidProof is returned when the root hash that's computed from a proof doesn't match the expected one.
+var ErrInvalidProof = errors.New("accumulator: the computed root hash doesn't match the expected one")
+
+// RootHash computes the root hash of an accumulator with the given leaves.
+func RootHash(salt string, leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return PlaceholderHash
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = parents(salt, level)
+	}
+	return level[0]
+}
+
+// Proof returns the proof of the leaf with the given index, in the format of Libra's gRPC API.
+func Proof(salt string, leaves [][]byte, index uint64) *types.AccumulatorProof {
+	proof := &types.AccumulatorProof{}
+	level := leaves
+	for i := 0; len(level) > 1; i++ {
+		sibling := PlaceholderHash
+		if index^1 < uint64(len(level)) {
+			sibling = level[index^1]
+		}
+		if !bytes.Equal(sibling, PlaceholderHash) {
+			proof.Bitmap |= 1 << uint(i)
+			// The siblings near the root are at the beginning of the list
+			proof.NonDefaultSiblings = append([][]byte{sibling}, proof.NonDefaultSiblings...)
+		}
+		level = parents(salt, level)
+		index /= 2
+	}
+	return proof
+}
+
+// Siblings returns the siblings of a proof, starting with the one at the bottom of the accumulator.
+func Siblings(proof *types.AccumulatorProof) ([][]byte, error) {
+	bitmap := proof.GetBitmap()
+	nonDefault := proof.GetNonDefaultSiblings()
+	if bits.OnesCount64(bitmap) != len(nonDefault) {
+		return nil, errors.New("accumulator: the number of siblings doesn't match the bitmap of the proof")
+	}
+	depth := bits.Len64(bitmap)
+	result := make([][]byte, depth)
+	next := len(nonDefault) - 1
+	for i := 0; i < depth; i++ {
+		if bitmap&(1<<uint(i)) == 0 {
+			result[i] = PlaceholderHash
+		} else {
+			result[i] = nonDefault[next]
+			next--
+		}
+	}
+	return result, nil
+}
+
+// VerifyRange verifies that the given consecutive leaves, starting with the leaf at index first,
+// are part of the accumulator with the given root hash.
+// firstProof and lastProof are the proofs of the first and the last leaf of the range.
+// Only the left siblings of the first proof and the right siblings of the last proof are needed,
+// the other nodes are computed from the leaves.
+func VerifyRange(salt string, rootHash []byte, first uint64, leaves [][]byte, firstProof, lastProof *types.AccumulatorProof) error {
+	if len(leaves) == 0 {
+		return nil
+	}
+	firstSiblings, err := Siblings(firstProof)
+	if err != nil {
+		return err
+	}
+	lastSiblings, err := Siblings(lastProof)
+	if err != nil {
+		return err
+	}
+	if len(firstSiblings) != len(lastSiblings) {
+		return errors.New("accumulator: the proofs of the first and the last leaf have different depths")
+	}
+
+	lo, hi := first, first+uint64(len(leaves))-1
+	if hi < lo || bits.Len64(hi) > len(firstSiblings) {
+		return errors.New("accumulator: the range of leaves doesn't fit into the accumulator of the proofs")
+	}
+	level := leaves
+	for i := range firstSiblings {
+		if lo%2 == 1 {
+			level = append([][]byte{firstSiblings[i]}, level...)
+			lo--
+		}
+		if hi%2 == 0 {
+			level = append(level[:len(level):len(level)], lastSiblings[i])
+			hi++
+		}
+		level = parents(salt, level)
+		lo /= 2
+		hi /= 2
+	}
+	if len(level) != 1 || !bytes.Equal(level[0], rootHash) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// parents returns the parent nodes of the given level.
+// If the number of nodes is odd, the last node gets a placeholder as right sibling.
+func parents(salt string, level [][]byte) [][]byte {
+	result := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		right := PlaceholderHash
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		result = append(result, parent(salt, level[i], right))
+	}
+	return result
+}
+
+// parent returns the hash of a node with the given children.
+// A node with only placeholder children is a placeholder itself.
+func parent(salt string, left, right []byte) []byte {
+	if bytes.Equal(left, PlaceholderHash) && bytes.Equal(right, PlaceholderHash) {
+		return PlaceholderHash
+	}
+	h := hashing.New(salt)
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
@@ -0,0 +1,107 @@
+package accumulator_test
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/philippgille/libra-sdk-go/internal/accumulator"
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+)
+
+const testSalt = "TestAccumulator"
+
+func testLeaves(n int) [][]byte {
+	var leaves [][]byte
+	for i := 0; i < n; i++ {
+		leaves = append(leaves, hashing.SHA3([]byte{byte(i)}))
+	}
+	return leaves
+}
+
+// TestVerifyRange tests if the proofs of all ranges of accumulators with different sizes are valid.
+func TestVerifyRange(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := testLeaves(n)
+		rootHash := accumulator.RootHash(testSalt, leaves)
+		for first := 0; first < n; first++ {
+			for last := first; last < n; last++ {
+				firstProof := accumulator.Proof(testSalt, leaves, uint64(first))
+				lastProof := accumulator.Proof(testSalt, leaves, uint64(last))
+				err := accumulator.VerifyRange(testSalt, rootHash, uint64(first), leaves[first:last+1], firstProof, lastProof)
+				if err != nil {
+					t.Fatalf("Leaves %v to %v of %v: %v", first, last, n, err)
+				}
+			}
+		}
+	}
+}
+
+// TestVerifyRangeInvalid tests if manipulated leaves and versions are detected.
+func TestVerifyRangeInvalid(t *testing.T) {
+	leaves := testLeaves(7)
+	rootHash := accumulator.RootHash(testSalt, leaves)
+	firstProof := accumulator.Proof(testSalt, leaves, 2)
+	lastProof := accumulator.Proof(testSalt, leaves, 4)
+
+	manipulated := [][]byte{leaves[2], hashing.SHA3([]byte("manipulated")), leaves[4]}
+	if err := accumulator.VerifyRange(testSalt, rootHash, 2, manipulated, firstProof, lastProof); err != accumulator.ErrInvalidProof {
+		t.Fatalf("Expected accumulator.ErrInvalidProof for a manipulated leaf, but was %v", err)
+	}
+	if err := accumulator.VerifyRange(testSalt, rootHash, 3, leaves[2:5], firstProof, lastProof); err != accumulator.ErrInvalidProof {
+		t.Fatalf("Expected accumulator.ErrInvalidProof for a wrong index, but was %v", err)
+	}
+}
+
+// TestRootHashEmpty tests if the root hash of an empty accumulator is the placeholder hash.
+func TestRootHashEmpty(t *testing.T) {
+	if string(accumulator.RootHash(testSalt, nil)) != string(accumulator.PlaceholderHash) {
+		t.Fatal("Expected the placeholder hash")
+	}
+}
+
+// TestKnownAnswer tests the root hash and proofs of a transaction accumulator against hashes that are built up
+// node by node in the test with SHA3-256, following the documentation of AccumulatorProof in Libra's protos:
+// Parents are the hash of the salt's hash and their concatenated children, and placeholder subtrees stay placeholders.
+// Unlike the other tests, it detects a wrong salt, sibling order or placeholder handling,
+// which would be consistent between RootHash, Proof and VerifyRange.
+func TestKnownAnswer(t *testing.T) {
+	leaves := testLeaves(5)
+	placeholder := make([]byte, 32)
+	copy(placeholder, "ACCUMULATOR_PLACEHOLDER_HASH")
+	node := func(left, right []byte) []byte {
+		salt := sha3.Sum256([]byte("TransactionAccumulator@@$$LIBRA$$@@"))
+		h := sha3.Sum256(append(append(salt[:], left...), right...))
+		return h[:]
+	}
+	// Leaves 0 to 3 form a full subtree, leaf 4 is padded with placeholders to 4 leaves
+	left := node(node(leaves[0], leaves[1]), node(leaves[2], leaves[3]))
+	right := node(node(leaves[4], placeholder), placeholder)
+
+	rootHash := accumulator.RootHash(hashing.TransactionAccumulatorSalt, leaves)
+	if expected := node(left, right); !bytes.Equal(rootHash, expected) {
+		t.Fatalf("Expected the root hash %x, but was %x", expected, rootHash)
+	}
+
+	testCases := []struct {
+		index    uint64
+		bitmap   uint64
+		siblings [][]byte
+	}{
+		{1, 7, [][]byte{right, node(leaves[2], leaves[3]), leaves[0]}},
+		// The siblings of the last leaf are placeholders, except for the left subtree
+		{4, 4, [][]byte{left}},
+	}
+	for _, tc := range testCases {
+		proof := accumulator.Proof(hashing.TransactionAccumulatorSalt, leaves, tc.index)
+		if proof.GetBitmap() != tc.bitmap || len(proof.GetNonDefaultSiblings()) != len(tc.siblings) {
+			t.Fatalf("Expected bitmap %b with %v siblings for leaf %v, but was %b with %v", tc.bitmap, len(tc.siblings), tc.index, proof.GetBitmap(), len(proof.GetNonDefaultSiblings()))
+		}
+		for i, sibling := range proof.GetNonDefaultSiblings() {
+			if !bytes.Equal(sibling, tc.siblings[i]) {
+				t.Fatalf("Expected sibling %v of leaf %v to be %x, but was %x", i, tc.index, tc.siblings[i], sibling)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package canonical implements Libra's canonical serialization,
+// which is used for computing the hashes of structs like StructTag and RawTransaction.
+//
+// Integers are encoded little-endian with their fixed size.
+// Byte arrays and strings are prefixed with their length as uint32.
+// Vectors are prefixed with their element count as uint32.
+// Enums are encoded as their variant index as uint32, followed by the variant's data.
+package canonical
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxLength limits the length of byte arrays and vectors during deserialization,
+// so that malformed input doesn't lead to huge allocations.
+const maxLength = 1 << 24
+
+// ErrTrailingBytes is returned by Deserializer.Finish() when not all input bytes were read.
+var ErrTrailingBytes = errors.New("canonical: trailing bytes after the serialized value")
+
+// Serializer serializes values into their canonical form.
+// The zero value is ready to use.
+type Serializer struct {
+	buf bytes.Buffer
+}
+
+// U8 serializes a single byte.
+func (s *Serializer) U8(v uint8) *Serializer {
+	s.buf.WriteByte(v)
+	return s
+}
+
+// Bool serializes a boolean as single byte.
+func (s *Serializer) Bool(v bool) *Serializer {
+	if v {
+		return s.U8(1)
+	}
+	return s.U8(0)
+}
+
+// U32 serializes a uint32.
+func (s *Serializer) U32(v uint32) *Serializer {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	s.buf.Write(b[:])
+	return s
+}
+
+// U64 serializes a uint64.
+func (s *Serializer) U64(v uint64) *Serializer {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	s.buf.Write(b[:])
+	return s
+}
+
+// Bytes serializes a variable length byte array.
+func (s *Serializer) Bytes(v []byte) *Serializer {
+	s.U32(uint32(len(v)))
+	s.buf.Write(v)
+	return s
+}
+
+// String serializes a string as variable length byte array.
+func (s *Serializer) String(v string) *Serializer {
+	return s.Bytes([]byte(v))
+}
+
+// Raw writes the given bytes as they are, without length prefix.
+func (s *Serializer) Raw(v []byte) *Serializer {
+	s.buf.Write(v)
+	return s
+}
+
+// Result returns the serialized bytes.
+func (s *Serializer) Result() []byte {
+	return s.buf.Bytes()
+}
+
+// Deserializer deserializes values from their canonical form.
+// The first error that occurs is kept and returned by Err() and Finish(),
+// subsequent reads return zero values.
+type Deserializer struct {
+	r   *bytes.Reader
+	err error
+}
+
+// NewDeserializer creates a Deserializer for the given bytes.
+func NewDeserializer(b []byte) *Deserializer {
+	return &Deserializer{
+		r: bytes.NewReader(b),
+	}
+}
+
+// U8 deserializes a single byte.
+func (d *Deserializer) U8() uint8 {
+	var b [1]byte
+	d.read(b[:])
+	return b[0]
+}
+
+// Bool deserializes a boolean.
+func (d *Deserializer) Bool() bool {
+	v := d.U8()
+	if v > 1 && d.err == nil {
+		d.err = fmt.Errorf("canonical: invalid boolean value %v", v)
+	}
+	return v == 1
+}
+
+// U32 deserializes a uint32.
+func (d *Deserializer) U32() uint32 {
+	var b [4]byte
+	d.read(b[:])
+	return binary.LittleEndian.Uint32(b[:])
+}
+
+// U64 deserializes a uint64.
+func (d *Deserializer) U64() uint64 {
+	var b [8]byte
+	d.read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Len deserializes the length prefix of a vector.
+func (d *Deserializer) Len() int {
+	l := d.U32()
+	if l > maxLength && d.err == nil {
+		d.err = fmt.Errorf("canonical: length %v exceeds the max length of %v", l, maxLength)
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(l)
+}
+
+// Bytes deserializes a variable length byte array.
+func (d *Deserializer) Bytes() []byte {
+	l := d.Len()
+	if d.err != nil {
+		return nil
+	}
+	if l > d.r.Len() {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := make([]byte, l)
+	d.read(b)
+	return b
+}
+
+// String deserializes a string.
+func (d *Deserializer) String() string {
+	return string(d.Bytes())
+}
+
+// Raw reads n bytes without length prefix.
+func (d *Deserializer) Raw(n int) []byte {
+	if n > d.r.Len() {
+		if d.err == nil {
+			d.err = io.ErrUnexpectedEOF
+		}
+		return nil
+	}
+	b := make([]byte, n)
+	d.read(b)
+	return b
+}
+
+// Err returns the first error that occurred during deserialization.
+func (d *Deserializer) Err() error {
+	return d.err
+}
+
+// Finish returns the first error that occurred during deserialization,
+// or ErrTrailingBytes if not all input bytes were read.
+func (d *Deserializer) Finish() error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.r.Len() != 0 {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+func (d *Deserializer) read(b []byte) {
+	if d.err != nil {
+		return
+	}
+	_, err := io.ReadFull(d.r, b)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	d.err = err
+}
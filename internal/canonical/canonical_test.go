@@ -0,0 +1,60 @@
+package canonical_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/philippgille/libra-sdk-go/internal/canonical"
+)
+
+// TestRoundTrip tests if values serialized with the Serializer are deserialized to the same values.
+func TestRoundTrip(t *testing.T) {
+	s := &canonical.Serializer{}
+	b := s.U8(7).Bool(true).U32(42).U64(1 << 40).Bytes([]byte{1, 2, 3}).String("LibraAccount").Raw([]byte{9, 9}).Result()
+
+	expectedPrefix := []byte{7, 1, 42, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 3, 0, 0, 0, 1, 2, 3}
+	if !bytes.HasPrefix(b, expectedPrefix) {
+		t.Fatalf("Unexpected serialization: %x", b)
+	}
+
+	d := canonical.NewDeserializer(b)
+	if v := d.U8(); v != 7 {
+		t.Fatalf("Expected 7, but was %v", v)
+	}
+	if v := d.Bool(); !v {
+		t.Fatal("Expected true, but was false")
+	}
+	if v := d.U32(); v != 42 {
+		t.Fatalf("Expected 42, but was %v", v)
+	}
+	if v := d.U64(); v != 1<<40 {
+		t.Fatalf("Expected %v, but was %v", uint64(1<<40), v)
+	}
+	if v := d.Bytes(); !bytes.Equal(v, []byte{1, 2, 3}) {
+		t.Fatalf("Expected 010203, but was %x", v)
+	}
+	if v := d.String(); v != "LibraAccount" {
+		t.Fatalf("Expected LibraAccount, but was %v", v)
+	}
+	if v := d.Raw(2); !bytes.Equal(v, []byte{9, 9}) {
+		t.Fatalf("Expected 0909, but was %x", v)
+	}
+	if err := d.Finish(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDeserializerErrors tests if the Deserializer detects truncated input and trailing bytes.
+func TestDeserializerErrors(t *testing.T) {
+	d := canonical.NewDeserializer([]byte{5, 0, 0, 0, 1, 2})
+	_ = d.Bytes()
+	if d.Err() == nil {
+		t.Fatal("Expected an error for truncated input")
+	}
+
+	d = canonical.NewDeserializer([]byte{1, 0, 0, 0, 0})
+	_ = d.U32()
+	if err := d.Finish(); err != canonical.ErrTrailingBytes {
+		t.Fatalf("Expected %v, but was %v", canonical.ErrTrailingBytes, err)
+	}
+}
@@ -0,0 +1,53 @@
+// Package hashing implements Libra's salted SHA3-256 hashing.
+//
+// Libra prefixes the data of each hashed type with the SHA3-256 hash of a type specific salt,
+// so that the hashes of different types can't collide.
+package hashing
+
+import (
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// libraHashSuffix is appended to each salt before hashing it.
+const libraHashSuffix = "@@$$LIBRA$$@@"
+
+// Salts of the types that are hashed by the SDK.
+const (
+	// AccessPathSalt is used for StructTag and ModuleId hashes in access paths.
+	AccessPathSalt = "VM_ACCESS_PATH"
+	// RawTransactionSalt is used for the hash that's signed by the sender of a transaction.
+	RawTransactionSalt = "RawTransaction"
+	// SignedTransactionSalt is used for the hash of a signed transaction in its TransactionInfo.
+	SignedTransactionSalt = "SignedTransaction"
+	// TransactionInfoSalt is used for the leaves of the transaction accumulator.
+	TransactionInfoSalt = "TransactionInfo"
+	// TransactionAccumulatorSalt is used for the internal nodes of the transaction accumulator.
+	TransactionAccumulatorSalt = "TransactionAccumulator"
+	// EventSalt is used for the leaves of the event accumulator of a transaction.
+	EventSalt = "ContractEvent"
+	// EventAccumulatorSalt is used for the internal nodes of the event accumulator of a transaction.
+	EventAccumulatorSalt = "EventAccumulator"
+)
+
+// New returns a SHA3-256 hash.Hash that's already prefixed with the hash of the given salt.
+func New(salt string) hash.Hash {
+	saltHash := sha3.Sum256([]byte(salt + libraHashSuffix))
+	h := sha3.New256()
+	h.Write(saltHash[:])
+	return h
+}
+
+// Sum returns the salted SHA3-256 hash of the given data.
+func Sum(salt string, data []byte) []byte {
+	h := New(salt)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// SHA3 returns the unsalted SHA3-256 hash of the given data.
+func SHA3(data []byte) []byte {
+	h := sha3.Sum256(data)
+	return h[:]
+}
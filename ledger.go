@@ -0,0 +1,209 @@
+package libra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// LedgerInfo is the latest ledger info of a node, which the validators sign after committing a block.
+type LedgerInfo struct {
+	// Version of the latest committed transaction
+	Version uint64
+	// Epoch of the validator set that signed the ledger info
+	Epoch uint64
+	// Timestamp of the latest committed block, generated by the block's proposer
+	Timestamp time.Time
+	// AccumulatorHash is the root hash of the transaction accumulator at the version
+	AccumulatorHash []byte
+	// Signers are the addresses of the validators that signed the ledger info
+	Signers []AccountAddress
+}
+
+// ledgerInfoJSON is the JSON representation of a LedgerInfo.
+type ledgerInfoJSON struct {
+	Version         uint64           `json:"version,string"`
+	Epoch           uint64           `json:"epoch_num,string"`
+	Timestamp       uint64           `json:"timestamp_usecs,string"`
+	AccumulatorHash hexBytes         `json:"transaction_accumulator_hash"`
+	Signers         []AccountAddress `json:"signers"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The timestamp is encoded as ledger timestamp in microseconds, like in the ledger info of the node.
+func (li LedgerInfo) MarshalJSON() ([]byte, error) {
+	v := ledgerInfoJSON{
+		Version:         li.Version,
+		Epoch:           li.Epoch,
+		AccumulatorHash: li.AccumulatorHash,
+		Signers:         li.Signers,
+	}
+	if !li.Timestamp.IsZero() {
+		v.Timestamp = LedgerTimestamp(li.Timestamp)
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (li *LedgerInfo) UnmarshalJSON(data []byte) error {
+	var v ledgerInfoJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*li = LedgerInfo{
+		Version:         v.Version,
+		Epoch:           v.Epoch,
+		AccumulatorHash: v.AccumulatorHash,
+		Signers:         v.Signers,
+	}
+	if v.Timestamp != 0 {
+		li.Timestamp = TimeFromLedgerTimestamp(v.Timestamp)
+	}
+	return nil
+}
+
+func ledgerInfoFromProto(ledgerInfoWithSigs *types.LedgerInfoWithSignatures) (LedgerInfo, error) {
+	ledgerInfo := ledgerInfoWithSigs.GetLedgerInfo()
+	result := LedgerInfo{
+		Version:         ledgerInfo.GetVersion(),
+		Epoch:           ledgerInfo.GetEpochNum(),
+		AccumulatorHash: ledgerInfo.GetTransactionAccumulatorHash(),
+	}
+	if ledgerInfo.GetTimestampUsecs() != 0 {
+		result.Timestamp = TimeFromLedgerTimestamp(ledgerInfo.GetTimestampUsecs())
+	}
+	for _, sig := range ledgerInfoWithSigs.GetSignatures() {
+		signer, err := AccountAddressFromBytes(sig.GetValidatorId())
+		if err != nil {
+			return LedgerInfo{}, fmt.Errorf("Invalid validator ID of a ledger info signature: %v", err)
+		}
+		result.Signers = append(result.Signers, signer)
+	}
+	return result, nil
+}
+
+// GetLedgerInfo returns the latest ledger info of the node, requested with an UpdateToLatestLedger request without request items.
+// The signatures aren't verified, only the addresses of the signers are returned.
+func (c Client) GetLedgerInfo(ctx context.Context) (LedgerInfo, error) {
+	res, err := c.updateToLatestLedger(ctx)
+	if err != nil {
+		return LedgerInfo{}, err
+	}
+	return ledgerInfoFromProto(res.GetLedgerInfoWithSigs())
+}
+
+// LagError is returned by a HealthCheck for a node whose ledger is lagging behind.
+type LagError struct {
+	LedgerInfo LedgerInfo
+	// VersionLag is the number of versions the node is behind the reference version
+	VersionLag uint64
+	// Age is the time since the timestamp of the node's latest ledger info
+	Age time.Duration
+	// Exceeded limits
+	VersionLagExceeded bool
+	AgeExceeded        bool
+}
+
+// Error implements the error interface.
+func (e LagError) Error() string {
+	var problems []string
+	if e.VersionLagExceeded {
+		problems = append(problems, fmt.Sprintf("%v versions behind", e.VersionLag))
+	}
+	if e.AgeExceeded {
+		problems = append(problems, fmt.Sprintf("the latest ledger info is %v old", e.Age.Round(time.Millisecond)))
+	}
+	return fmt.Sprintf("The node is lagging at version %v: %v", e.LedgerInfo.Version, strings.Join(problems, ", "))
+}
+
+// HealthCheck checks if nodes are lagging behind, by version compared to other nodes
+// or by the age of their latest ledger info according to the local clock.
+// Limits that are 0 aren't checked.
+type HealthCheck struct {
+	// MaxVersionLag is the maximum number of versions a node can be behind the reference version
+	MaxVersionLag uint64
+	// MaxAge is the maximum time since the timestamp of the node's latest ledger info.
+	// Keep in mind that the ledger only advances when transactions are committed,
+	// so on a ledger without traffic the age increases even for healthy nodes.
+	MaxAge time.Duration
+}
+
+// DefaultHealthCheck flags nodes that are more than 1000 versions behind
+// or whose latest ledger info is more than a minute old.
+var DefaultHealthCheck = HealthCheck{
+	MaxVersionLag: 1000,
+	MaxAge:        time.Minute,
+}
+
+// CheckLedgerInfo checks the ledger info of a node against the reference version, e.g. the highest version of all nodes,
+// and the age of its timestamp at the given time. A LagError is returned if a limit is exceeded.
+func (h HealthCheck) CheckLedgerInfo(ledgerInfo LedgerInfo, referenceVersion uint64, now time.Time) error {
+	e := LagError{
+		LedgerInfo: ledgerInfo,
+		Age:        now.Sub(ledgerInfo.Timestamp),
+	}
+	if referenceVersion > ledgerInfo.Version {
+		e.VersionLag = referenceVersion - ledgerInfo.Version
+	}
+	e.VersionLagExceeded = h.MaxVersionLag != 0 && e.VersionLag > h.MaxVersionLag
+	e.AgeExceeded = h.MaxAge != 0 && e.Age > h.MaxAge
+	if e.VersionLagExceeded || e.AgeExceeded {
+		return e
+	}
+	return nil
+}
+
+// NodeHealth is the result of a HealthCheck for a node.
+type NodeHealth struct {
+	Address string
+	// LedgerInfo is the latest ledger info of the node, if it could be requested
+	LedgerInfo LedgerInfo
+	// Err is the error of the request or a LagError, or nil if the node is healthy
+	Err error
+}
+
+// Check requests the latest ledger info from all nodes of the client concurrently and checks them.
+// The highest version of all nodes is the reference version, so for a client that's created with NewClient(...)
+// only the age of the ledger info is checked.
+func (h HealthCheck) Check(ctx context.Context, c Client) []NodeHealth {
+	var result []NodeHealth
+	var clients []Client
+	if c.pool == nil {
+		result = []NodeHealth{{Address: c.address}}
+		clients = []Client{c}
+	} else {
+		for _, n := range c.pool.nodes {
+			result = append(result, NodeHealth{Address: n.address})
+			clients = append(clients, Client{address: n.address, acc: n.acc})
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result[i].LedgerInfo, result[i].Err = clients[i].GetLedgerInfo(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	var referenceVersion uint64
+	for _, nh := range result {
+		if nh.Err == nil && nh.LedgerInfo.Version > referenceVersion {
+			referenceVersion = nh.LedgerInfo.Version
+		}
+	}
+	now := time.Now()
+	for i, nh := range result {
+		if nh.Err == nil {
+			result[i].Err = h.CheckLedgerInfo(nh.LedgerInfo, referenceVersion, now)
+		}
+	}
+	return result
+}
@@ -0,0 +1,182 @@
+package libra_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+)
+
+// TestGetLedgerInfo tests if the version, epoch, timestamp, accumulator hash and signers of the latest ledger info are returned.
+func TestGetLedgerInfo(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptMint,
+		Code: []byte{13, 14, 15},
+	})
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ledgerTime := time.Unix(1563000000, 123456000)
+	s.Clock = func() time.Time {
+		return ledgerTime
+	}
+	s.Epoch = 3
+	s.Validators = []libra.AccountAddress{{1}, {2}}
+	if _, err := s.Mint(libra.AccountAddress{5}, libra.Libra); err != nil {
+		t.Fatal(err)
+	}
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ledgerInfo, err := c.GetLedgerInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ledgerInfo.Version != s.Version() || ledgerInfo.Epoch != 3 || !ledgerInfo.Timestamp.Equal(ledgerTime) {
+		t.Fatalf("Expected version %v, epoch 3 and timestamp %v, but was %+v", s.Version(), ledgerTime, ledgerInfo)
+	}
+	if len(ledgerInfo.AccumulatorHash) != 32 {
+		t.Fatalf("Expected a 32 byte accumulator hash, but was %x", ledgerInfo.AccumulatorHash)
+	}
+	if diff := deep.Equal(ledgerInfo.Signers, s.Validators); diff != nil {
+		t.Fatal(diff)
+	}
+
+	data, err := json.Marshal(ledgerInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded libra.LedgerInfo
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Timestamp.Equal(ledgerInfo.Timestamp) {
+		t.Fatalf("Expected the timestamp %v, but was %v", ledgerInfo.Timestamp, decoded.Timestamp)
+	}
+	decoded.Timestamp = ledgerInfo.Timestamp
+	if diff := deep.Equal(decoded, ledgerInfo); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+// TestHealthCheckLedgerInfo tests if ledger infos that exceed the version lag or age are flagged.
+func TestHealthCheckLedgerInfo(t *testing.T) {
+	now := time.Unix(1563000000, 0)
+	h := libra.HealthCheck{MaxVersionLag: 10, MaxAge: time.Minute}
+	testCases := []struct {
+		version            uint64
+		timestamp          time.Time
+		versionLagExceeded bool
+		ageExceeded        bool
+	}{
+		{100, now, false, false},
+		{90, now.Add(-time.Minute), false, false},
+		{89, now, true, false},
+		{100, now.Add(-time.Minute - time.Second), false, true},
+		{0, time.Time{}, true, true},
+	}
+	for _, tc := range testCases {
+		err := h.CheckLedgerInfo(libra.LedgerInfo{Version: tc.version, Timestamp: tc.timestamp}, 100, now)
+		if !tc.versionLagExceeded && !tc.ageExceeded {
+			if err != nil {
+				t.Fatalf("Expected version %v at %v to be healthy, but was %v", tc.version, tc.timestamp, err)
+			}
+			continue
+		}
+		lagErr, ok := err.(libra.LagError)
+		if !ok {
+			t.Fatalf("Expected a libra.LagError, but was %v", err)
+		}
+		if lagErr.VersionLagExceeded != tc.versionLagExceeded || lagErr.AgeExceeded != tc.ageExceeded {
+			t.Fatalf("Unexpected error for version %v at %v: %v", tc.version, tc.timestamp, lagErr)
+		}
+	}
+
+	// Limits that are 0 aren't checked
+	if err := (libra.HealthCheck{}).CheckLedgerInfo(libra.LedgerInfo{}, 100, now); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestHealthCheck tests if the nodes of a multi-node client that are lagging behind by version or wall-clock time are flagged.
+func TestHealthCheck(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptMint,
+		Code: []byte{13, 14, 15},
+	})
+	var servers []*libratest.Server
+	var addresses []string
+	for i := 0; i < 3; i++ {
+		s, err := libratest.NewServer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		servers = append(servers, s)
+		addresses = append(addresses, s.Addr)
+	}
+	// The first node is ahead of the others by 3 versions, the last node's ledger is an hour old
+	for i := 0; i < 4; i++ {
+		if _, err := servers[0].Mint(libra.AccountAddress{5}, libra.Libra); err != nil {
+			t.Fatal(err)
+		}
+	}
+	servers[2].Clock = func() time.Time {
+		return time.Now().Add(-time.Hour)
+	}
+
+	c, err := libra.NewMultiNodeClient(addresses, time.Second, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	h := libra.HealthCheck{MaxVersionLag: 2, MaxAge: time.Minute}
+	result := h.Check(context.Background(), c)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 results, but were %v", len(result))
+	}
+	for i, nh := range result {
+		if nh.Address != addresses[i] {
+			t.Fatalf("Expected address %v, but was %v", addresses[i], nh.Address)
+		}
+	}
+	if result[0].Err != nil || result[0].LedgerInfo.Version != servers[0].Version() {
+		t.Fatalf("Expected the first node to be healthy at version %v, but was %+v", servers[0].Version(), result[0])
+	}
+	if lagErr, ok := result[1].Err.(libra.LagError); !ok || !lagErr.VersionLagExceeded || lagErr.AgeExceeded || lagErr.VersionLag != 3 {
+		t.Fatalf("Expected the second node to lag by 3 versions, but was %v", result[1].Err)
+	}
+	if lagErr, ok := result[2].Err.(libra.LagError); !ok || !lagErr.VersionLagExceeded || !lagErr.AgeExceeded {
+		t.Fatalf("Expected the third node to lag by version and age, but was %v", result[2].Err)
+	}
+
+	// Unreachable nodes are reported with the request error
+	servers[1].Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result = h.Check(ctx, c)
+	if _, ok := result[1].Err.(libra.LagError); ok || result[1].Err == nil {
+		t.Fatalf("Expected a request error for the closed node, but was %v", result[1].Err)
+	}
+
+	// For a single node only the age is checked
+	single, err := libra.NewClient(servers[2].Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer single.Close()
+	result = h.Check(context.Background(), single)
+	if lagErr, ok := result[0].Err.(libra.LagError); len(result) != 1 || !ok || lagErr.VersionLagExceeded || !lagErr.AgeExceeded {
+		t.Fatalf("Expected the node to lag by age, but was %+v", result)
+	}
+}
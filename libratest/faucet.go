@@ -0,0 +1,39 @@
+package libratest
+
+import (
+	"net/http"
+	"strconv"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// FaucetHandler returns an HTTP handler that speaks the protocol of Libra's faucet and mints with Mint(...).
+// It can be used as stand-in for the testnet's faucet, e.g. with httptest.NewServer(s.FaucetHandler())
+// and the faucet package's client.
+//
+// Requests are POST requests with the query parameters "amount" (in micro-libra) and "address" (hex encoded).
+// The response body is the sequence number of the association account after the mint transaction.
+func (s *Server) FaucetHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		amount, err := strconv.ParseUint(r.URL.Query().Get("amount"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid amount: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		receiver, err := libra.ParseAccountAddress(r.URL.Query().Get("address"))
+		if err != nil {
+			http.Error(w, "Invalid address: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		seqNo, err := s.Mint(receiver, libra.Amount(amount))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(strconv.FormatUint(seqNo, 10)))
+	})
+}
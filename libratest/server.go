@@ -0,0 +1,791 @@
+// Package libratest provides a fake Libra validator node for testing code that uses the SDK
+// without network access.
+//
+// The fake node implements the AdmissionControl gRPC service with an in-memory ledger.
+// Submitted transactions are executed immediately if their sequence number is the sender's current one.
+// Transactions with a higher sequence number are kept back until the gap is filled, like Libra's mempool does.
+// Peer-to-peer transfers, account creations and mints are executed and emit payment events,
+// and authentication key rotations change the sender's authentication key,
+// if the scripts are registered with libra.RegisterScript(...). Other programs only cost gas.
+// Published modules are recorded by their bytecode hash, publishing the same module again
+// is rejected with the runtime status DuplicateModuleName.
+// Signatures are only verified if Server.VerifySignatures is set.
+package libratest
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/internal/accumulator"
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/admission_control"
+	"github.com/philippgille/libra-sdk-go/rpc/mempool"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// DefaultGasUsed is the amount of gas each executed transaction uses, unless Server.GasUsed is changed.
+const DefaultGasUsed = 10
+
+// Server is a fake Libra validator node.
+type Server struct {
+	// Addr is the address the gRPC server listens on, e.g. "127.0.0.1:12345".
+	// Use it for libra.NewClient(...).
+	Addr string
+	// GasUsed is the amount of gas each executed transaction uses.
+	// Changes must be made before transactions are submitted.
+	GasUsed uint64
+	// VerifySignatures enables the verification of the signatures of submitted transactions,
+	// and of whether the public key matches the sender's authentication key.
+	// Transactions that fail are rejected with the VM validation status InvalidSignature or InvalidAuthKey.
+	VerifySignatures bool
+	// Clock returns the ledger's timestamp, which is used for the ledger infos and for rejecting expired transactions.
+	// If nil, the local clock is used. Set it to simulate validators whose clocks differ from the local one.
+	// Changes must be made before requests are sent.
+	Clock func() time.Time
+	// Epoch is the epoch number of the ledger infos.
+	// Changes must be made before requests are sent.
+	Epoch uint64
+	// Validators are the signers of the ledger infos. The signatures themselves are empty.
+	// Changes must be made before requests are sent.
+	Validators []libra.AccountAddress
+
+	grpcServer *grpc.Server
+
+	lock     sync.Mutex
+	accounts map[libra.AccountAddress]*libra.AccountResource
+	// Committed transactions, the index is the version
+	txs []committedTx
+	// Hashes of the transaction infos of the committed transactions, which are the leaves of the transaction accumulator
+	txInfoHashes [][]byte
+	// Submitted transactions with a sequence number that's too high to be executed yet, by sender and sequence number
+	parked map[libra.AccountAddress]map[uint64]parkedTx
+	// Number of upcoming requests that fail with failErr
+	failCount int
+	failErr   error
+	// Number of upcoming submissions that are rejected with rejectStatus
+	rejectCount  int
+	rejectStatus *types.VMStatus
+	// Hashes of the published modules by account
+	modules map[libra.AccountAddress]map[string]bool
+	// Number of received UpdateToLatestLedger requests
+	ledgerRequests int
+}
+
+type committedTx struct {
+	signedTx *types.SignedTransaction
+	rawTx    *types.RawTransaction
+	sender   libra.AccountAddress
+	info     *types.TransactionInfo
+	events   []*types.Event
+}
+
+type parkedTx struct {
+	signedTx *types.SignedTransaction
+	rawTx    *types.RawTransaction
+}
+
+// NewServer starts a new fake validator node that listens on a random local port.
+// Close() must be called when the server isn't needed anymore.
+func NewServer() (*Server, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Addr:       lis.Addr().String(),
+		GasUsed:    DefaultGasUsed,
+		grpcServer: grpc.NewServer(),
+		accounts:   make(map[libra.AccountAddress]*libra.AccountResource),
+		parked:     make(map[libra.AccountAddress]map[uint64]parkedTx),
+		modules:    make(map[libra.AccountAddress]map[string]bool),
+	}
+	admission_control.RegisterAdmissionControlServer(s.grpcServer, s)
+	go s.grpcServer.Serve(lis)
+	return s, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+}
+
+// SetAccount creates or overwrites an account.
+func (s *Server) SetAccount(addr libra.AccountAddress, accRes libra.AccountResource) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.accounts[addr] = &accRes
+}
+
+// Account returns the account resource of the given account.
+// false is returned if the account doesn't exist.
+func (s *Server) Account(addr libra.AccountAddress) (libra.AccountResource, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	accRes, ok := s.accounts[addr]
+	if !ok {
+		return libra.AccountResource{}, false
+	}
+	return *accRes, true
+}
+
+// FailRequests lets the next n requests fail with the given error,
+// e.g. status.Error(codes.Unavailable, "...") for simulating an overloaded node.
+func (s *Server) FailRequests(n int, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failCount = n
+	s.failErr = err
+}
+
+// injectedFailure returns the error of FailRequests(...) if the current request should fail.
+func (s *Server) injectedFailure() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failCount == 0 {
+		return nil
+	}
+	s.failCount--
+	return s.failErr
+}
+
+// RejectSubmissions lets the VM reject the next n submitted transactions with the given status,
+// e.g. a verification status list for simulating modules that fail verification.
+func (s *Server) RejectSubmissions(n int, status *types.VMStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rejectCount = n
+	s.rejectStatus = status
+}
+
+// now returns the ledger's timestamp, see Server.Clock.
+func (s *Server) now() time.Time {
+	if s.Clock != nil {
+		return s.Clock()
+	}
+	return time.Now()
+}
+
+// Version returns the version of the latest committed transaction.
+func (s *Server) Version() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.version()
+}
+
+// LedgerRequests returns the number of UpdateToLatestLedger requests the server received,
+// including the ones that failed, e.g. for checking how often a client polls.
+func (s *Server) LedgerRequests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.ledgerRequests
+}
+
+// version returns the latest version. The caller must hold the lock.
+func (s *Server) version() uint64 {
+	if len(s.txs) == 0 {
+		return 0
+	}
+	return uint64(len(s.txs) - 1)
+}
+
+// SubmitTransaction implements admission_control.AdmissionControlServer.
+func (s *Server) SubmitTransaction(ctx context.Context, req *admission_control.SubmitTransactionRequest) (*admission_control.SubmitTransactionResponse, error) {
+	if err := s.injectedFailure(); err != nil {
+		return nil, err
+	}
+	signedTx := req.GetSignedTxn()
+	rawTx := &types.RawTransaction{}
+	if err := proto.Unmarshal(signedTx.GetRawTxnBytes(), rawTx); err != nil {
+		return vmStatusResponse(&types.VMStatus{
+			ErrorType: &types.VMStatus_Deserialization{Deserialization: types.BinaryError_Malformed},
+		}), nil
+	}
+	sender, err := libra.AccountAddressFromBytes(rawTx.GetSenderAccount())
+	if err != nil {
+		return vmStatusResponse(&types.VMStatus{
+			ErrorType: &types.VMStatus_Deserialization{Deserialization: types.BinaryError_Malformed},
+		}), nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	accRes, ok := s.accounts[sender]
+	if !ok {
+		return validationResponse(types.VMValidationStatusCode_SendingAccountDoesNotExist), nil
+	}
+	if s.VerifySignatures {
+		switch transactionFromProto(signedTx).VerifyAuthKey(accRes.AuthKey) {
+		case nil:
+		case libra.ErrAuthKeyMismatch:
+			return validationResponse(types.VMValidationStatusCode_InvalidAuthKey), nil
+		default:
+			return validationResponse(types.VMValidationStatusCode_InvalidSignature), nil
+		}
+	}
+	if rawTx.GetExpirationTime() != 0 && uint64(s.now().Unix()) >= rawTx.GetExpirationTime() {
+		return validationResponse(types.VMValidationStatusCode_TransactionExpired), nil
+	}
+	if s.rejectCount > 0 {
+		s.rejectCount--
+		return vmStatusResponse(s.rejectStatus), nil
+	}
+	if sdkRawTx, err := libra.RawTransactionFromBytes(signedTx.GetRawTxnBytes()); err == nil && sdkRawTx.Program != nil {
+		if err := sdkRawTx.Program.CheckArguments(); err != nil {
+			return vmStatusResponse(scriptVerificationStatus(types.VMVerificationErrorKind_TypeMismatch, err.Error())), nil
+		}
+	}
+	for _, module := range rawTx.GetProgram().GetModules() {
+		if s.modules[sender][string(hashing.SHA3(module))] {
+			return vmStatusResponse(&types.VMStatus{
+				ErrorType: &types.VMStatus_Execution{
+					Execution: &types.ExecutionStatus{
+						ExecutionStatus: &types.ExecutionStatus_RuntimeStatus{RuntimeStatus: types.RuntimeStatus_DuplicateModuleName},
+					},
+				},
+			}), nil
+		}
+	}
+	seqNo := rawTx.GetSequenceNumber()
+	if seqNo < accRes.SequenceNo {
+		return validationResponse(types.VMValidationStatusCode_SequenceNumberTooOld), nil
+	}
+	if _, ok := s.parked[sender][seqNo]; ok {
+		return mempoolResponse(mempool.MempoolAddTransactionStatusCode_InvalidUpdate), nil
+	}
+
+	if s.parked[sender] == nil {
+		s.parked[sender] = make(map[uint64]parkedTx)
+	}
+	s.parked[sender][seqNo] = parkedTx{
+		signedTx: signedTx,
+		rawTx:    rawTx,
+	}
+	s.executeParked(sender)
+
+	return acceptedResponse(), nil
+}
+
+// executeParked executes the parked transactions of the sender, as long as their sequence numbers are consecutive.
+// The caller must hold the lock.
+func (s *Server) executeParked(sender libra.AccountAddress) {
+	for {
+		accRes := s.accounts[sender]
+		tx, ok := s.parked[sender][accRes.SequenceNo]
+		if !ok {
+			return
+		}
+		delete(s.parked[sender], accRes.SequenceNo)
+		s.execute(sender, tx)
+	}
+}
+
+// execute executes the transaction and commits it to the ledger.
+// The caller must hold the lock.
+func (s *Server) execute(sender libra.AccountAddress, tx parkedTx) {
+	accRes := s.accounts[sender]
+	accRes.SequenceNo++
+	fee, err := libra.Amount(s.GasUsed).Mul(tx.rawTx.GetGasUnitPrice())
+	if err != nil {
+		fee = accRes.Balance
+	}
+	if fee > accRes.Balance {
+		fee = accRes.Balance
+	}
+	accRes.Balance -= fee
+	events := s.executeProgram(sender, tx.rawTx.GetProgram())
+	for _, module := range tx.rawTx.GetProgram().GetModules() {
+		if s.modules[sender] == nil {
+			s.modules[sender] = make(map[string]bool)
+		}
+		s.modules[sender][string(hashing.SHA3(module))] = true
+	}
+
+	txHash, err := transactionFromProto(tx.signedTx).Hash()
+	if err != nil {
+		// Transactions without a program can't be hashed canonically,
+		// so the hash is just a hash of the protobuf encoded transaction.
+		signedTxBytes, _ := proto.Marshal(tx.signedTx)
+		txHash = hashing.SHA3(signedTxBytes)
+	}
+	info := libra.TransactionInfo{
+		SignedTransactionHash: txHash,
+		EventRootHash:         eventRootHash(events),
+		GasUsed:               s.GasUsed,
+	}
+	s.txs = append(s.txs, committedTx{
+		signedTx: tx.signedTx,
+		rawTx:    tx.rawTx,
+		sender:   sender,
+		info: &types.TransactionInfo{
+			SignedTransactionHash: info.SignedTransactionHash,
+			EventRootHash:         info.EventRootHash,
+			GasUsed:               info.GasUsed,
+		},
+		events: events,
+	})
+	s.txInfoHashes = append(s.txInfoHashes, info.Hash())
+}
+
+// transactionFromProto converts a protobuf signed transaction into the SDK's type.
+func transactionFromProto(signedTx *types.SignedTransaction) libra.Transaction {
+	return libra.Transaction{
+		RawBytes:     signedTx.GetRawTxnBytes(),
+		SenderPubKey: signedTx.GetSenderPublicKey(),
+		SenderSig:    signedTx.GetSenderSignature(),
+	}
+}
+
+// eventRootHash returns the root hash of the event accumulator of the given events.
+func eventRootHash(events []*types.Event) []byte {
+	var sdkEvents []libra.Event
+	for _, event := range events {
+		addr, _ := libra.AccountAddressFromBytes(event.GetAccessPath().GetAddress())
+		sdkEvents = append(sdkEvents, libra.Event{
+			Address:    addr,
+			Path:       event.GetAccessPath().GetPath(),
+			SequenceNo: event.GetSequenceNumber(),
+			Data:       event.GetEventData(),
+		})
+	}
+	return libra.EventRootHash(sdkEvents)
+}
+
+// executeProgram executes the program of a transaction and returns the emitted events.
+// Only the peer-to-peer transfer, account creation, mint and authentication key rotation scripts are supported,
+// if they're registered with libra.RegisterScript(...). Other programs don't have any effect.
+// The caller must hold the lock.
+func (s *Server) executeProgram(sender libra.AccountAddress, program *types.Program) []*types.Event {
+	script, ok := libra.ScriptByHash(hashing.SHA3(program.GetCode()))
+	if !ok {
+		return nil
+	}
+	args := program.GetArguments()
+	switch script.Name {
+	case libra.ScriptPeerToPeerTransfer, libra.ScriptCreateAccount, libra.ScriptMint:
+		if len(args) != 2 || args[0].GetType() != types.TransactionArgument_ADDRESS || args[1].GetType() != types.TransactionArgument_U64 || len(args[1].GetData()) != 8 {
+			return nil
+		}
+		receiver, err := libra.AccountAddressFromBytes(args[0].GetData())
+		if err != nil {
+			return nil
+		}
+		amount := libra.Amount(binary.LittleEndian.Uint64(args[1].GetData()))
+		switch script.Name {
+		case libra.ScriptCreateAccount:
+			return s.createAccount(sender, receiver, amount)
+		case libra.ScriptMint:
+			return s.mint(sender, receiver, amount)
+		}
+		return s.transfer(sender, receiver, amount)
+	case libra.ScriptRotateAuthenticationKey:
+		if len(args) != 1 || args[0].GetType() != types.TransactionArgument_BYTEARRAY || len(args[0].GetData()) != libra.AccountAddressLength {
+			return nil
+		}
+		s.accounts[sender].AuthKey = append([]byte{}, args[0].GetData()...)
+	}
+	return nil
+}
+
+// createAccount creates the account with the given address and transfers the initial amount to it.
+// If the account already exists or the sender's balance is too low, nothing happens,
+// like when Libra's create_account script aborts.
+// The caller must hold the lock.
+func (s *Server) createAccount(sender, newAddr libra.AccountAddress, initialAmount libra.Amount) []*types.Event {
+	if _, ok := s.accounts[newAddr]; ok || initialAmount > s.accounts[sender].Balance {
+		return nil
+	}
+	if initialAmount == 0 {
+		s.accounts[newAddr] = &libra.AccountResource{
+			AuthKey: newAddr.Bytes(),
+		}
+		return nil
+	}
+	return s.transfer(sender, newAddr, initialAmount)
+}
+
+// mint adds the amount to the receiver's balance and returns the received payment event.
+// The receiver's account is created if it doesn't exist yet.
+// If the sender isn't the association account or the balance would overflow, nothing happens.
+// The caller must hold the lock.
+func (s *Server) mint(sender, receiver libra.AccountAddress, amount libra.Amount) []*types.Event {
+	if sender != libra.AssociationAddress {
+		return nil
+	}
+	receiverRes, ok := s.accounts[receiver]
+	if !ok {
+		receiverRes = &libra.AccountResource{
+			AuthKey: receiver.Bytes(),
+		}
+	}
+	balance, err := receiverRes.Balance.Add(amount)
+	if err != nil {
+		return nil
+	}
+	s.accounts[receiver] = receiverRes
+	receiverRes.Balance = balance
+
+	receivedEvent := &types.Event{
+		AccessPath:     accesspath.ReceivedEvents(receiver.Bytes()),
+		SequenceNumber: receiverRes.ReceivedEvents,
+		EventData:      libra.PaymentEvent{Amount: amount, Counterparty: sender}.ToBytes(),
+	}
+	receiverRes.ReceivedEvents++
+	return []*types.Event{receivedEvent}
+}
+
+// Mint commits a transaction of the association account (libra.AssociationAddress) with the mint script,
+// which adds the amount to the receiver's balance and creates the receiver's account if it doesn't exist.
+// The association account is created if it doesn't exist. The mint script must be registered with libra.RegisterScript(...).
+// Like Libra's faucet, it returns the sequence number of the association account after the transaction.
+func (s *Server) Mint(receiver libra.AccountAddress, amount libra.Amount) (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sender := libra.AssociationAddress
+	senderRes, ok := s.accounts[sender]
+	if !ok {
+		senderRes = &libra.AccountResource{
+			AuthKey: sender.Bytes(),
+		}
+		s.accounts[sender] = senderRes
+	}
+	rawTx, err := libra.NewMintTransaction(sender, senderRes.SequenceNo, receiver, amount, libra.DefaultFeePolicy.Default)
+	if err != nil {
+		return 0, err
+	}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	protoRawTx := &types.RawTransaction{}
+	if err := proto.Unmarshal(rawTxBytes, protoRawTx); err != nil {
+		return 0, err
+	}
+	s.execute(sender, parkedTx{
+		signedTx: &types.SignedTransaction{RawTxnBytes: rawTxBytes},
+		rawTx:    protoRawTx,
+	})
+	s.executeParked(sender)
+	return s.accounts[sender].SequenceNo, nil
+}
+
+// transfer moves the amount from the sender to the receiver and returns the sent and received payment events.
+// The receiver's account is created if it doesn't exist yet. If the sender's balance is too low, nothing happens,
+// like when Libra's transfer script aborts.
+// The caller must hold the lock.
+func (s *Server) transfer(sender, receiver libra.AccountAddress, amount libra.Amount) []*types.Event {
+	senderRes := s.accounts[sender]
+	if amount > senderRes.Balance {
+		return nil
+	}
+	receiverRes, ok := s.accounts[receiver]
+	if !ok {
+		receiverRes = &libra.AccountResource{
+			AuthKey: receiver.Bytes(),
+		}
+		s.accounts[receiver] = receiverRes
+	}
+	senderRes.Balance -= amount
+	receiverRes.Balance += amount
+
+	sentEvent := &types.Event{
+		AccessPath:     accesspath.SentEvents(sender.Bytes()),
+		SequenceNumber: senderRes.SentEvents,
+		EventData:      libra.PaymentEvent{Amount: amount, Counterparty: receiver}.ToBytes(),
+	}
+	senderRes.SentEvents++
+	receivedEvent := &types.Event{
+		AccessPath:     accesspath.ReceivedEvents(receiver.Bytes()),
+		SequenceNumber: receiverRes.ReceivedEvents,
+		EventData:      libra.PaymentEvent{Amount: amount, Counterparty: sender}.ToBytes(),
+	}
+	receiverRes.ReceivedEvents++
+	return []*types.Event{sentEvent, receivedEvent}
+}
+
+// UpdateToLatestLedger implements admission_control.AdmissionControlServer.
+func (s *Server) UpdateToLatestLedger(ctx context.Context, req *types.UpdateToLatestLedgerRequest) (*types.UpdateToLatestLedgerResponse, error) {
+	s.lock.Lock()
+	s.ledgerRequests++
+	s.lock.Unlock()
+	if err := s.injectedFailure(); err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var responseItems []*types.ResponseItem
+	for _, item := range req.GetRequestedItems() {
+		var responseItem *types.ResponseItem
+		switch request := item.GetRequestedItems().(type) {
+		case *types.RequestItem_GetAccountStateRequest:
+			responseItem = &types.ResponseItem{
+				ResponseItems: &types.ResponseItem_GetAccountStateResponse{
+					GetAccountStateResponse: &types.GetAccountStateResponse{
+						AccountStateWithProof: s.accountStateWithProof(request.GetAccountStateRequest.GetAddress()),
+					},
+				},
+			}
+		case *types.RequestItem_GetAccountTransactionBySequenceNumberRequest:
+			responseItem = &types.ResponseItem{
+				ResponseItems: &types.ResponseItem_GetAccountTransactionBySequenceNumberResponse{
+					GetAccountTransactionBySequenceNumberResponse: s.accountTransaction(request.GetAccountTransactionBySequenceNumberRequest),
+				},
+			}
+		case *types.RequestItem_GetEventsByEventAccessPathRequest:
+			responseItem = &types.ResponseItem{
+				ResponseItems: &types.ResponseItem_GetEventsByEventAccessPathResponse{
+					GetEventsByEventAccessPathResponse: s.events(request.GetEventsByEventAccessPathRequest),
+				},
+			}
+		case *types.RequestItem_GetTransactionsRequest:
+			responseItem = &types.ResponseItem{
+				ResponseItems: &types.ResponseItem_GetTransactionsResponse{
+					GetTransactionsResponse: s.transactions(request.GetTransactionsRequest),
+				},
+			}
+		default:
+			responseItem = &types.ResponseItem{}
+		}
+		responseItems = append(responseItems, responseItem)
+	}
+
+	return &types.UpdateToLatestLedgerResponse{
+		ResponseItems: responseItems,
+		LedgerInfoWithSigs: &types.LedgerInfoWithSignatures{
+			LedgerInfo: &types.LedgerInfo{
+				Version:                    s.version(),
+				TransactionAccumulatorHash: accumulator.RootHash(hashing.TransactionAccumulatorSalt, s.txInfoHashes),
+				EpochNum:                   s.Epoch,
+				TimestampUsecs:             libra.LedgerTimestamp(s.now()),
+			},
+			Signatures: s.validatorSignatures(),
+		},
+	}, nil
+}
+
+// validatorSignatures returns an empty signature for each validator.
+func (s *Server) validatorSignatures() []*types.ValidatorSignature {
+	var result []*types.ValidatorSignature
+	for _, validator := range s.Validators {
+		result = append(result, &types.ValidatorSignature{ValidatorId: validator.Bytes()})
+	}
+	return result
+}
+
+// accountStateWithProof returns the account state of the given address.
+// If the account doesn't exist, the blob is nil.
+// The caller must hold the lock.
+func (s *Server) accountStateWithProof(addrBytes []byte) *types.AccountStateWithProof {
+	result := &types.AccountStateWithProof{
+		Version: s.version(),
+	}
+	addr, err := libra.AccountAddressFromBytes(addrBytes)
+	if err != nil {
+		return result
+	}
+	accRes, ok := s.accounts[addr]
+	if !ok {
+		return result
+	}
+	result.Blob = &types.AccountStateBlob{
+		Blob: accountStateBlob(*accRes),
+	}
+	return result
+}
+
+// accountTransaction returns the committed transaction of an account with a specific sequence number.
+// If there's no such transaction, only the proof of the current sequence number is set.
+// The caller must hold the lock.
+func (s *Server) accountTransaction(req *types.GetAccountTransactionBySequenceNumberRequest) *types.GetAccountTransactionBySequenceNumberResponse {
+	addr, err := libra.AccountAddressFromBytes(req.GetAccount())
+	if err == nil {
+		for version, tx := range s.txs {
+			if tx.sender == addr && tx.rawTx.GetSequenceNumber() == req.GetSequenceNumber() {
+				return &types.GetAccountTransactionBySequenceNumberResponse{
+					SignedTransactionWithProof: s.signedTransactionWithProof(uint64(version), req.GetFetchEvents()),
+				}
+			}
+		}
+	}
+	return &types.GetAccountTransactionBySequenceNumberResponse{
+		ProofOfCurrentSequenceNumber: s.accountStateWithProof(req.GetAccount()),
+	}
+}
+
+// signedTransactionWithProof returns the committed transaction with the given version.
+// The caller must hold the lock.
+func (s *Server) signedTransactionWithProof(version uint64, fetchEvents bool) *types.SignedTransactionWithProof {
+	tx := s.txs[version]
+	result := &types.SignedTransactionWithProof{
+		Version:           version,
+		SignedTransaction: tx.signedTx,
+		Proof: &types.SignedTransactionProof{
+			TransactionInfo: tx.info,
+		},
+	}
+	if fetchEvents {
+		result.Events = &types.EventsList{
+			Events: tx.events,
+		}
+	}
+	return result
+}
+
+// transactions returns a list of committed transactions.
+// The caller must hold the lock.
+func (s *Server) transactions(req *types.GetTransactionsRequest) *types.GetTransactionsResponse {
+	txList := &types.TransactionListWithProof{}
+	start := req.GetStartVersion()
+	if start < uint64(len(s.txs)) {
+		end := start + req.GetLimit()
+		if end > uint64(len(s.txs)) || end < start {
+			end = uint64(len(s.txs))
+		}
+		var eventsForVersions []*types.EventsList
+		for _, tx := range s.txs[start:end] {
+			txList.Transactions = append(txList.Transactions, tx.signedTx)
+			txList.Infos = append(txList.Infos, tx.info)
+			eventsForVersions = append(eventsForVersions, &types.EventsList{Events: tx.events})
+		}
+		if req.GetFetchEvents() {
+			txList.EventsForVersions = &types.EventsForVersions{
+				EventsForVersion: eventsForVersions,
+			}
+		}
+		if end > start {
+			txList.FirstTransactionVersion = &wrappers.UInt64Value{Value: start}
+			txList.ProofOfFirstTransaction = accumulator.Proof(hashing.TransactionAccumulatorSalt, s.txInfoHashes, start)
+			txList.ProofOfLastTransaction = accumulator.Proof(hashing.TransactionAccumulatorSalt, s.txInfoHashes, end-1)
+		}
+	}
+	return &types.GetTransactionsResponse{
+		TxnListWithProof: txList,
+	}
+}
+
+// events returns the events of an event handle.
+// The caller must hold the lock.
+func (s *Server) events(req *types.GetEventsByEventAccessPathRequest) *types.GetEventsByEventAccessPathResponse {
+	var matching []*types.EventWithProof
+	for version, tx := range s.txs {
+		for i, event := range tx.events {
+			if proto.Equal(event.GetAccessPath(), req.GetAccessPath()) {
+				matching = append(matching, &types.EventWithProof{
+					TransactionVersion: uint64(version),
+					EventIndex:         uint64(i),
+					Event:              event,
+				})
+			}
+		}
+	}
+
+	var result []*types.EventWithProof
+	start := req.GetStartEventSeqNum()
+	if req.GetAscending() {
+		for _, eventWithProof := range matching {
+			if uint64(len(result)) == req.GetLimit() {
+				break
+			}
+			if eventWithProof.GetEvent().GetSequenceNumber() >= start {
+				result = append(result, eventWithProof)
+			}
+		}
+	} else {
+		for i := len(matching) - 1; i >= 0; i-- {
+			if uint64(len(result)) == req.GetLimit() {
+				break
+			}
+			if matching[i].GetEvent().GetSequenceNumber() <= start {
+				result = append(result, matching[i])
+			}
+		}
+	}
+
+	response := &types.GetEventsByEventAccessPathResponse{
+		EventsWithProof: result,
+	}
+	if uint64(len(result)) < req.GetLimit() {
+		response.ProofOfLatestEvent = s.accountStateWithProof(req.GetAccessPath().GetAddress())
+	}
+	return response
+}
+
+// accountStateBlob encodes an account state blob that only contains the account resource.
+func accountStateBlob(accRes libra.AccountResource) []byte {
+	key := accesspath.ResourcePath(accesspath.AccountResourceTag)
+	val := accRes.ToBlob()
+	b := make([]byte, 0, 12+len(key)+len(val))
+	b = appendUint32(b, 1)
+	b = appendUint32(b, uint32(len(key)))
+	b = append(b, key...)
+	b = appendUint32(b, uint32(len(val)))
+	return append(b, val...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func acceptedResponse() *admission_control.SubmitTransactionResponse {
+	return &admission_control.SubmitTransactionResponse{
+		Status: &admission_control.SubmitTransactionResponse_AcStatus{
+			AcStatus: &admission_control.AdmissionControlStatus{
+				Code: admission_control.AdmissionControlStatusCode_Accepted,
+			},
+		},
+	}
+}
+
+func vmStatusResponse(status *types.VMStatus) *admission_control.SubmitTransactionResponse {
+	return &admission_control.SubmitTransactionResponse{
+		Status: &admission_control.SubmitTransactionResponse_VmStatus{
+			VmStatus: status,
+		},
+	}
+}
+
+func validationResponse(code types.VMValidationStatusCode) *admission_control.SubmitTransactionResponse {
+	return vmStatusResponse(&types.VMStatus{
+		ErrorType: &types.VMStatus_Validation{
+			Validation: &types.VMValidationStatus{
+				Code: code,
+			},
+		},
+	})
+}
+
+// scriptVerificationStatus returns the VM status of a script that failed verification.
+func scriptVerificationStatus(kind types.VMVerificationErrorKind, message string) *types.VMStatus {
+	return &types.VMStatus{
+		ErrorType: &types.VMStatus_Verification{
+			Verification: &types.VMVerificationStatusList{
+				StatusList: []*types.VMVerificationStatus{{
+					StatusKind: types.VMVerificationStatus_SCRIPT,
+					ErrorKind:  kind,
+					Message:    message,
+				}},
+			},
+		},
+	}
+}
+
+func mempoolResponse(code mempool.MempoolAddTransactionStatusCode) *admission_control.SubmitTransactionResponse {
+	return &admission_control.SubmitTransactionResponse{
+		Status: &admission_control.SubmitTransactionResponse_MempoolStatus{
+			MempoolStatus: &mempool.MempoolAddTransactionStatus{
+				Code: code,
+			},
+		},
+	}
+}
@@ -0,0 +1,263 @@
+package libra
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/philippgille/libra-sdk-go/rpc/admission_control"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// ErrNoHealthyNode is returned by a multi-node client when none of its nodes is reachable.
+var ErrNoHealthyNode = errors.New("None of the nodes is healthy")
+
+// NodeStatus is the health status of a node of a multi-node client.
+type NodeStatus struct {
+	Address string
+	Healthy bool
+	// Version of the latest ledger info the node returned
+	Version uint64
+	// LastCheck is the time of the latest health check or request
+	LastCheck time.Time
+	// LastError is the error of the latest failed health check or request
+	LastError error
+}
+
+// node is a node of a nodePool.
+type node struct {
+	address string
+	acc     admission_control.AdmissionControlClient
+	// status is guarded by the lock of the nodePool
+	status NodeStatus
+}
+
+// nodePool is an AdmissionControlClient that distributes requests among multiple nodes.
+//
+// Reads (UpdateToLatestLedger) are routed to the healthy nodes with the highest known ledger version,
+// alternating between nodes with the same version.
+// Transactions are submitted to one node at a time.
+// Requests that fail with the gRPC status Unavailable are retried with the next node.
+// Resubmitting the same signed transaction is safe, because its sequence number prevents it from being executed twice.
+type nodePool struct {
+	nodes []*node
+	// lock guards the status of all nodes and next
+	lock sync.Mutex
+	// next is used for alternating between equally fresh nodes
+	next      uint
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMultiNodeClient creates a new Libra client that's connected to multiple validator or full nodes.
+//
+// All nodes are health-checked via UpdateToLatestLedger initially and then in the given interval.
+// With an interval of 0 or less there are no periodic health checks, so a node's status only changes with the requests it gets.
+// Reads are sent to the node with the most recent ledger version, and if a node isn't available,
+// the request is retried with the next node. Transactions are submitted to one node at a time.
+// If none of the nodes passes the initial health check, ErrNoHealthyNode is returned.
+// When all nodes fail, requests are retried according to DefaultRetryPolicy, see Client.WithRetryPolicy(...).
+//
+// The connections are kept open until Close() is called on the client.
+func NewMultiNodeClient(addresses []string, dialTimeout time.Duration, healthCheckInterval time.Duration) (Client, error) {
+	if len(addresses) == 0 {
+		return Client{}, errors.New("At least one address is required")
+	}
+	pool := &nodePool{
+		stop: make(chan struct{}),
+	}
+	var conns []*grpc.ClientConn
+	for _, address := range addresses {
+		// Without grpc.WithBlock() dialing doesn't fail for unreachable nodes,
+		// so they can become healthy later.
+		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		if err != nil {
+			for _, conn := range conns {
+				conn.Close()
+			}
+			return Client{}, err
+		}
+		conns = append(conns, conn)
+		pool.nodes = append(pool.nodes, &node{
+			address: address,
+			acc:     admission_control.NewAdmissionControlClient(conn),
+			status: NodeStatus{
+				Address: address,
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	pool.checkHealth(ctx)
+	if !pool.anyHealthy() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+		return Client{}, ErrNoHealthyNode
+	}
+	if healthCheckInterval > 0 {
+		go pool.checkHealthPeriodically(healthCheckInterval, dialTimeout)
+	}
+
+	c := Client{
+		address: addresses[0],
+		conns:   conns,
+		acc:     pool,
+		pool:    pool,
+		sent:    newSentTxs(),
+	}
+	return c.WithRetryPolicy(DefaultRetryPolicy), nil
+}
+
+// NodeStatus returns the health status of all nodes of a multi-node client.
+// For a client that's created with NewClient(...), nil is returned.
+func (c Client) NodeStatus() []NodeStatus {
+	if c.pool == nil {
+		return nil
+	}
+	c.pool.lock.Lock()
+	defer c.pool.lock.Unlock()
+	var result []NodeStatus
+	for _, n := range c.pool.nodes {
+		result = append(result, n.status)
+	}
+	return result
+}
+
+// checkHealthPeriodically runs health checks until the pool is closed.
+func (p *nodePool) checkHealthPeriodically(interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			p.checkHealth(ctx)
+			cancel()
+		}
+	}
+}
+
+// checkHealth requests the latest ledger info from all nodes concurrently.
+func (p *nodePool) checkHealth(ctx context.Context) {
+	wg := sync.WaitGroup{}
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			res, err := n.acc.UpdateToLatestLedger(ctx, &types.UpdateToLatestLedgerRequest{})
+			p.report(n, res, err, true)
+		}(n)
+	}
+	wg.Wait()
+}
+
+// report updates the status of a node after a health check or request.
+// Failed health checks make a node unhealthy, but for other requests only errors
+// that indicate an unreachable node do, because other errors can be caused by the request.
+func (p *nodePool) report(n *node, res *types.UpdateToLatestLedgerResponse, err error, healthCheck bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	n.status.LastCheck = time.Now()
+	if err != nil {
+		if healthCheck || isUnavailable(err) {
+			n.status.Healthy = false
+			n.status.LastError = err
+		}
+		return
+	}
+	n.status.Healthy = true
+	if res != nil {
+		if version := res.GetLedgerInfoWithSigs().GetLedgerInfo().GetVersion(); version > n.status.Version {
+			n.status.Version = version
+		}
+	}
+}
+
+func (p *nodePool) anyHealthy() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, n := range p.nodes {
+		if n.status.Healthy {
+			return true
+		}
+	}
+	return false
+}
+
+// candidates returns all nodes in the order they should be tried:
+// Healthy nodes first, with the most recent version first. Nodes with the same version are rotated.
+// Unhealthy nodes are included at the end, because they might have recovered since the last health check.
+func (p *nodePool) candidates() []*node {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.next++
+	offset := int(p.next)
+	result := make([]*node, len(p.nodes))
+	for i := range p.nodes {
+		result[i] = p.nodes[(i+offset)%len(p.nodes)]
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].status, result[j].status
+		if a.Healthy != b.Healthy {
+			return a.Healthy
+		}
+		return a.Version > b.Version
+	})
+	return result
+}
+
+// UpdateToLatestLedger implements admission_control.AdmissionControlClient.
+func (p *nodePool) UpdateToLatestLedger(ctx context.Context, in *types.UpdateToLatestLedgerRequest, opts ...grpc.CallOption) (*types.UpdateToLatestLedgerResponse, error) {
+	var lastErr error
+	for _, n := range p.candidates() {
+		res, err := n.acc.UpdateToLatestLedger(ctx, in, opts...)
+		p.report(n, res, err, false)
+		if err == nil || !isUnavailable(err) || ctx.Err() != nil {
+			return res, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// SubmitTransaction implements admission_control.AdmissionControlClient.
+// The transaction is submitted to one node at a time.
+// If the node isn't available, the same signed transaction is submitted to the next node.
+//
+// When a node became unavailable after it received the transaction, the transaction can still be committed.
+// In that case the next node might reject the resubmitted transaction because of its sequence number,
+// so use Client.WaitForTransaction(...) for finding out if such a transaction was committed.
+func (p *nodePool) SubmitTransaction(ctx context.Context, in *admission_control.SubmitTransactionRequest, opts ...grpc.CallOption) (*admission_control.SubmitTransactionResponse, error) {
+	var lastErr error
+	for _, n := range p.candidates() {
+		res, err := n.acc.SubmitTransaction(ctx, in, opts...)
+		p.report(n, nil, err, false)
+		if err == nil || !isUnavailable(err) || ctx.Err() != nil {
+			return res, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// close stops the periodic health checks.
+func (p *nodePool) close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+	})
+}
+
+// isUnavailable returns true if the error is a gRPC error with the status Unavailable.
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
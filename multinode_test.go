@@ -0,0 +1,90 @@
+package libra_test
+
+import (
+	"testing"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+)
+
+// TestMultiNodeClientFailover tests if a multi-node client keeps working when one of its nodes goes down.
+func TestMultiNodeClientFailover(t *testing.T) {
+	addr := libra.AccountAddress{1}
+	var servers []*libratest.Server
+	var addresses []string
+	for i := 0; i < 2; i++ {
+		s, err := libratest.NewServer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		s.SetAccount(addr, libra.AccountResource{Balance: 42})
+		servers = append(servers, s)
+		addresses = append(addresses, s.Addr)
+	}
+
+	c, err := libra.NewMultiNodeClient(addresses, time.Second, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	for _, status := range c.NodeStatus() {
+		if !status.Healthy {
+			t.Fatalf("Expected node %v to be healthy, but it wasn't: %v", status.Address, status.LastError)
+		}
+	}
+
+	servers[0].Close()
+	// Every node gets a request at least once
+	for i := 0; i < 3; i++ {
+		accState, err := c.GetAccountState(addr.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if accState.AccountResource.Balance != 42 {
+			t.Fatalf("Expected balance 42, but was %v", accState.AccountResource.Balance)
+		}
+	}
+	if status := c.NodeStatus()[0]; status.Healthy {
+		t.Fatal("Expected the closed node to be unhealthy")
+	}
+}
+
+// TestMultiNodeClientNoHealthyNode tests if libra.NewMultiNodeClient(...) fails when no node is reachable.
+func TestMultiNodeClientNoHealthyNode(t *testing.T) {
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	_, err = libra.NewMultiNodeClient([]string{s.Addr}, 500*time.Millisecond, time.Hour)
+	if err != libra.ErrNoHealthyNode {
+		t.Fatalf("Expected libra.ErrNoHealthyNode, but was %v", err)
+	}
+}
+
+// TestMultiNodeClientWithoutPeriodicHealthChecks tests if a health check interval of 0 or less disables the periodic health checks.
+func TestMultiNodeClientWithoutPeriodicHealthChecks(t *testing.T) {
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.SetAccount(libra.AccountAddress{1}, libra.AccountResource{Balance: 42})
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		c, err := libra.NewMultiNodeClient([]string{s.Addr}, time.Second, interval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		accState, err := c.GetAccountState(libra.AccountAddress{1}.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if accState.AccountResource.Balance != 42 {
+			t.Fatalf("Expected balance 42, but was %v", accState.AccountResource.Balance)
+		}
+		c.Close()
+	}
+}
@@ -0,0 +1,354 @@
+package libra
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// ErrSummaryMismatch is returned when a transaction doesn't match the summary it's supposed to have.
+var ErrSummaryMismatch = errors.New("The transaction doesn't match its summary")
+
+// ErrInvalidSignature is returned when a transaction's signature isn't valid.
+var ErrInvalidSignature = errors.New("Invalid signature")
+
+// TransactionSummary is a human-readable summary of a raw transaction.
+// It's meant to be shown to whoever signs the transaction, e.g. on an air-gapped machine.
+type TransactionSummary struct {
+	// Hash is the hash of the raw transaction, which is what gets signed
+	Hash       []byte
+	Sender     AccountAddress
+	SequenceNo uint64
+	// Script is the name of the transaction script, if it's registered (see RegisterScript(...))
+	Script string
+	// ScriptHash is the SHA3-256 hash of the script's bytecode
+	ScriptHash []byte
+	// Arguments are the formatted script arguments.
+	// Addresses and byte arrays are hex encoded, U64 values are decimal numbers.
+	Arguments []string
+	// Modules is the number of modules that are published by the transaction
+	Modules int
+	// WriteSet contains the formatted ops of write set transactions, with the access path and value length.
+	// Write set transactions don't have a script.
+	WriteSet       []string
+	MaxGasAmount   uint64
+	GasUnitPrice   Amount
+	ExpirationTime uint64
+}
+
+// transactionSummaryJSON is the JSON representation of a TransactionSummary.
+type transactionSummaryJSON struct {
+	Hash           hexBytes       `json:"hash"`
+	Sender         AccountAddress `json:"sender"`
+	SequenceNo     uint64         `json:"sequence_number,string"`
+	Script         string         `json:"script,omitempty"`
+	ScriptHash     hexBytes       `json:"script_hash"`
+	Arguments      []string       `json:"arguments"`
+	Modules        int            `json:"modules"`
+	WriteSet       []string       `json:"write_set,omitempty"`
+	MaxGasAmount   uint64         `json:"max_gas_amount,string"`
+	GasUnitPrice   Amount         `json:"gas_unit_price"`
+	ExpirationTime uint64         `json:"expiration_time,string"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s TransactionSummary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transactionSummaryJSON{
+		Hash:           s.Hash,
+		Sender:         s.Sender,
+		SequenceNo:     s.SequenceNo,
+		Script:         s.Script,
+		ScriptHash:     s.ScriptHash,
+		Arguments:      s.Arguments,
+		Modules:        s.Modules,
+		WriteSet:       s.WriteSet,
+		MaxGasAmount:   s.MaxGasAmount,
+		GasUnitPrice:   s.GasUnitPrice,
+		ExpirationTime: s.ExpirationTime,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *TransactionSummary) UnmarshalJSON(data []byte) error {
+	var v transactionSummaryJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = TransactionSummary{
+		Hash:           v.Hash,
+		Sender:         v.Sender,
+		SequenceNo:     v.SequenceNo,
+		Script:         v.Script,
+		ScriptHash:     v.ScriptHash,
+		Arguments:      v.Arguments,
+		Modules:        v.Modules,
+		WriteSet:       v.WriteSet,
+		MaxGasAmount:   v.MaxGasAmount,
+		GasUnitPrice:   v.GasUnitPrice,
+		ExpirationTime: v.ExpirationTime,
+	}
+	return nil
+}
+
+// String returns the summary as multi-line text for showing it to a user.
+func (s TransactionSummary) String() string {
+	script := "0x" + hex.EncodeToString(s.ScriptHash)
+	if s.Script != "" {
+		script = s.Script + " (" + script + ")"
+	}
+	expiration := "none"
+	if s.ExpirationTime != 0 {
+		expiration = time.Unix(int64(s.ExpirationTime), 0).UTC().Format(time.RFC3339)
+	}
+	lines := []string{
+		"Sender:           " + s.Sender.String(),
+		"Sequence number:  " + strconv.FormatUint(s.SequenceNo, 10),
+	}
+	if len(s.WriteSet) > 0 {
+		lines = append(lines, "Write set:        "+strings.Join(s.WriteSet, "\n                  "))
+	} else {
+		lines = append(lines,
+			"Script:           "+script,
+			"Arguments:        "+strings.Join(s.Arguments, ", "),
+			"Modules:          "+strconv.Itoa(s.Modules),
+		)
+	}
+	lines = append(lines,
+		"Max gas amount:   "+strconv.FormatUint(s.MaxGasAmount, 10),
+		"Gas unit price:   "+strconv.FormatUint(s.GasUnitPrice.MicroLibra(), 10)+" micro-libra",
+		"Expiration time:  "+expiration,
+		"Hash:             0x"+hex.EncodeToString(s.Hash),
+	)
+	return strings.Join(lines, "\n")
+}
+
+// Summary creates a human-readable summary of the raw transaction.
+func (rt RawTransaction) Summary() (TransactionSummary, error) {
+	hash, err := rt.Hash()
+	if err != nil {
+		return TransactionSummary{}, err
+	}
+	result := TransactionSummary{
+		Hash:           hash,
+		Sender:         rt.Sender,
+		SequenceNo:     rt.SequenceNo,
+		Arguments:      []string{},
+		MaxGasAmount:   rt.MaxGasAmount,
+		GasUnitPrice:   rt.GasUnitPrice,
+		ExpirationTime: rt.ExpirationTime,
+	}
+	if rt.WriteSet != nil {
+		for _, op := range rt.WriteSet.Ops {
+			result.WriteSet = append(result.WriteSet, formatWriteOp(op))
+		}
+		return result, nil
+	}
+	result.ScriptHash = hashing.SHA3(rt.Program.Code)
+	result.Modules = len(rt.Program.Modules)
+	result.Script = registeredScriptName(rt.Program.Code)
+	for _, arg := range rt.Program.Arguments {
+		result.Arguments = append(result.Arguments, formatArgument(arg))
+	}
+	return result, nil
+}
+
+// Verify checks if the signed transaction is the one that's described by the summary
+// and if its signature is valid.
+// ErrSummaryMismatch or ErrInvalidSignature is returned if not.
+//
+// It doesn't check if the public key belongs to the sender's account,
+// because the account's authentication key can be rotated.
+func (s TransactionSummary) Verify(tx Transaction) error {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return err
+	}
+	if err := s.check(rawTx); err != nil {
+		return err
+	}
+	if len(tx.SenderPubKey) != ed25519.PublicKeySize || !ed25519.Verify(tx.SenderPubKey, s.Hash, tx.SenderSig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// check returns ErrSummaryMismatch if the summary doesn't describe the given raw transaction.
+// The script name isn't compared, because the registered scripts can differ between machines.
+func (s TransactionSummary) check(rawTx RawTransaction) error {
+	actual, err := rawTx.Summary()
+	if err != nil {
+		return err
+	}
+	actual.Script = s.Script
+	// nil and empty lists are equal
+	if len(s.Arguments) == 0 && len(actual.Arguments) == 0 {
+		actual.Arguments = s.Arguments
+	}
+	if len(s.ScriptHash) == 0 && len(actual.ScriptHash) == 0 {
+		actual.ScriptHash = s.ScriptHash
+	}
+	if !reflect.DeepEqual(s, actual) {
+		return ErrSummaryMismatch
+	}
+	return nil
+}
+
+// registeredScriptName returns the name of the registered script with the given code.
+// An empty string is returned if there's no such script.
+func registeredScriptName(code []byte) string {
+	script, _ := ScriptByHash(hashing.SHA3(code))
+	return script.Name
+}
+
+// formatArgument formats a transaction argument for TransactionSummary.
+func formatArgument(arg TransactionArgument) string {
+	switch arg.Type {
+	case types.TransactionArgument_U64:
+		if len(arg.Data) == 8 {
+			return strconv.FormatUint(binary.LittleEndian.Uint64(arg.Data), 10)
+		}
+	case types.TransactionArgument_STRING:
+		return strconv.Quote(string(arg.Data))
+	}
+	return "0x" + hex.EncodeToString(arg.Data)
+}
+
+// formatWriteOp formats a write op for TransactionSummary.
+func formatWriteOp(op WriteOp) string {
+	if op.Type == types.WriteOpType_Delete {
+		return "delete " + formatAccessPath(op.AccessPath)
+	}
+	return fmt.Sprintf("write %v (%v bytes)", formatAccessPath(op.AccessPath), len(op.Value))
+}
+
+// UnsignedTransaction is a raw transaction with its summary,
+// which is the portable format for signing transactions offline.
+//
+// Its JSON encoding contains the hex encoded protobuf raw transaction and the summary.
+// When decoding it, the summary is checked against the raw transaction,
+// so a manipulated summary leads to ErrSummaryMismatch.
+type UnsignedTransaction struct {
+	RawTransaction RawTransaction
+	Summary        TransactionSummary
+}
+
+// unsignedTransactionJSON is the JSON representation of an UnsignedTransaction.
+type unsignedTransactionJSON struct {
+	RawBytes hexBytes           `json:"raw_txn_bytes"`
+	Summary  TransactionSummary `json:"summary"`
+}
+
+// NewUnsignedTransaction creates an unsigned transaction with the summary of the given raw transaction.
+func NewUnsignedTransaction(rawTx RawTransaction) (UnsignedTransaction, error) {
+	summary, err := rawTx.Summary()
+	if err != nil {
+		return UnsignedTransaction{}, err
+	}
+	return UnsignedTransaction{
+		RawTransaction: rawTx,
+		Summary:        summary,
+	}, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (ut UnsignedTransaction) MarshalJSON() ([]byte, error) {
+	rawBytes, err := ut.RawTransaction.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(unsignedTransactionJSON{
+		RawBytes: rawBytes,
+		Summary:  ut.Summary,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It returns ErrSummaryMismatch if the summary doesn't match the raw transaction.
+func (ut *UnsignedTransaction) UnmarshalJSON(data []byte) error {
+	var v unsignedTransactionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	rawTx, err := RawTransactionFromBytes(v.RawBytes)
+	if err != nil {
+		return err
+	}
+	if rawTx.Program == nil && rawTx.WriteSet == nil {
+		return errors.New("The raw transaction doesn't have a payload")
+	}
+	if err := v.Summary.check(rawTx); err != nil {
+		return err
+	}
+	*ut = UnsignedTransaction{
+		RawTransaction: rawTx,
+		Summary:        v.Summary,
+	}
+	return nil
+}
+
+// Sign signs the raw transaction with the given signer.
+// The returned transaction can be stored and sent later with Client.SendTx(...).
+// Before sending it, TransactionSummary.Verify(...) can be used to check it against the summary that was shown to the signer.
+func (ut UnsignedTransaction) Sign(signer Signer) (Transaction, error) {
+	if err := ut.Summary.check(ut.RawTransaction); err != nil {
+		return Transaction{}, err
+	}
+	return ut.RawTransaction.Sign(signer)
+}
+
+// SummarizedTransaction is a signed transaction with the summary of its raw transaction,
+// which is the portable format for submitting transactions that were signed offline.
+type SummarizedTransaction struct {
+	Transaction Transaction
+	Summary     TransactionSummary
+}
+
+// summarizedTransactionJSON is the JSON representation of a SummarizedTransaction.
+type summarizedTransactionJSON struct {
+	Transaction Transaction        `json:"signed_transaction"`
+	Summary     TransactionSummary `json:"summary"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (st SummarizedTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(summarizedTransactionJSON{
+		Transaction: st.Transaction,
+		Summary:     st.Summary,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It doesn't verify the transaction, use Verify() for that.
+func (st *SummarizedTransaction) UnmarshalJSON(data []byte) error {
+	var v summarizedTransactionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*st = SummarizedTransaction{
+		Transaction: v.Transaction,
+		Summary:     v.Summary,
+	}
+	return nil
+}
+
+// Verify checks if the transaction matches the summary and if its signature is valid.
+// See TransactionSummary.Verify(...).
+func (st SummarizedTransaction) Verify() error {
+	return st.Summary.Verify(st.Transaction)
+}
+
+// String returns the summary and the signer's public key as multi-line text.
+func (st SummarizedTransaction) String() string {
+	return fmt.Sprintf("%v\nPublic key:       0x%x", st.Summary, st.Transaction.SenderPubKey)
+}
@@ -0,0 +1,83 @@
+package libra_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// TestOfflineSigning tests the workflow of creating an unsigned transaction file,
+// signing it offline and verifying the signed transaction before sending it.
+func TestOfflineSigning(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedTx, err := libra.NewUnsignedTransaction(newTestRawTx(libra.AccountAddressFromPublicKey(publicKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"0x" + strings.Repeat("02", libra.AccountAddressLength), "4"}; strings.Join(unsignedTx.Summary.Arguments, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected arguments %v, but was %v", expected, unsignedTx.Summary.Arguments)
+	}
+	unsignedJSON, err := json.Marshal(unsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Offline
+	var decoded libra.UnsignedTransaction
+	if err := json.Unmarshal(unsignedJSON, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := decoded.Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedJSON, err := json.Marshal(libra.SummarizedTransaction{
+		Transaction: tx,
+		Summary:     decoded.Summary,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Online
+	var summarizedTx libra.SummarizedTransaction
+	if err := json.Unmarshal(signedJSON, &summarizedTx); err != nil {
+		t.Fatal(err)
+	}
+	if err := summarizedTx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := unsignedTx.Summary.Verify(summarizedTx.Transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	summarizedTx.Transaction.SenderSig[0]++
+	if err := summarizedTx.Verify(); err != libra.ErrInvalidSignature {
+		t.Fatalf("Expected libra.ErrInvalidSignature, but was %v", err)
+	}
+}
+
+// TestUnsignedTransactionManipulatedSummary tests if decoding an unsigned transaction
+// whose summary doesn't match the raw transaction fails.
+func TestUnsignedTransactionManipulatedSummary(t *testing.T) {
+	unsignedTx, err := libra.NewUnsignedTransaction(newTestRawTx(libra.AccountAddress{1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedTx.Summary.Arguments[1] = "4000000"
+	unsignedJSON, err := json.Marshal(unsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded libra.UnsignedTransaction
+	if err := json.Unmarshal(unsignedJSON, &decoded); err != libra.ErrSummaryMismatch {
+		t.Fatalf("Expected libra.ErrSummaryMismatch, but was %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package libra
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/philippgille/libra-sdk-go/internal/accumulator"
+	"github.com/philippgille/libra-sdk-go/internal/canonical"
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// ProofError is returned when the data of a response doesn't match its proof.
+// This means that the node returned manipulated or inconsistent data.
+type ProofError struct {
+	// Version of the transaction whose data or proof is invalid
+	Version uint64
+	Reason  string
+}
+
+// Error implements the error interface.
+func (e ProofError) Error() string {
+	return fmt.Sprintf("Invalid proof of the transaction with version %v: %v", e.Version, e.Reason)
+}
+
+// Hash returns the hash of the transaction info, which is the leaf of the ledger's transaction accumulator.
+// It's the salted SHA3-256 hash of the transaction info in Libra's canonical serialization.
+func (ti TransactionInfo) Hash() []byte {
+	s := &canonical.Serializer{}
+	s.Raw(ti.SignedTransactionHash).Raw(ti.StateRootHash).Raw(ti.EventRootHash).U64(ti.GasUsed)
+	return hashing.Sum(hashing.TransactionInfoSalt, s.Result())
+}
+
+// Hash returns the hash of the event, which is the leaf of the event accumulator of its transaction.
+// It's the salted SHA3-256 hash of the event in Libra's canonical serialization.
+func (e Event) Hash() []byte {
+	s := &canonical.Serializer{}
+	s.Bytes(e.Address.Bytes()).Bytes(e.Path).U64(e.SequenceNo).Bytes(e.Data)
+	return hashing.Sum(hashing.EventSalt, s.Result())
+}
+
+// EventRootHash returns the root hash of the event accumulator of the given events,
+// which is stored in the TransactionInfo of the transaction that emitted them.
+func EventRootHash(events []Event) []byte {
+	var leaves [][]byte
+	for _, event := range events {
+		leaves = append(leaves, event.Hash())
+	}
+	return accumulator.RootHash(hashing.EventAccumulatorSalt, leaves)
+}
+
+// verifyTransactionList verifies that the committed transactions match their transaction infos,
+// and that the transaction infos are part of the ledger with the given ledger info.
+// Events are only verified if they were fetched.
+// The signatures of the ledger info aren't verified.
+func verifyTransactionList(ledgerInfo *types.LedgerInfo, txs []CommittedTransaction, txList *types.TransactionListWithProof, eventsFetched bool) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	var leaves [][]byte
+	for _, tx := range txs {
+		txHash, err := tx.Transaction.Hash()
+		if err != nil {
+			return ProofError{Version: tx.Version, Reason: "The transaction can't be hashed: " + err.Error()}
+		}
+		if !bytes.Equal(txHash, tx.Info.SignedTransactionHash) {
+			return ProofError{Version: tx.Version, Reason: "The transaction doesn't match the hash in the transaction info"}
+		}
+		if eventsFetched && !bytes.Equal(EventRootHash(tx.Events), tx.Info.EventRootHash) {
+			return ProofError{Version: tx.Version, Reason: "The events don't match the event root hash in the transaction info"}
+		}
+		leaves = append(leaves, tx.Info.Hash())
+	}
+	last := txs[len(txs)-1].Version
+	if last > ledgerInfo.GetVersion() {
+		return ProofError{Version: last, Reason: "The transaction is newer than the ledger info"}
+	}
+	err := accumulator.VerifyRange(hashing.TransactionAccumulatorSalt, ledgerInfo.GetTransactionAccumulatorHash(), txs[0].Version, leaves,
+		txList.GetProofOfFirstTransaction(), txList.GetProofOfLastTransaction())
+	if err != nil {
+		return ProofError{Version: txs[0].Version, Reason: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package libra_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/admission_control"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestHashesKnownAnswer tests the hashes of transaction infos and events against hashes that are computed in the test
+// with SHA3-256 from canonical serialization bytes that are written out by hand, following Libra's canonical serialization
+// spec and salted hashing: The data is prefixed with the hash of the type's salt with the suffix "@@$$LIBRA$$@@".
+func TestHashesKnownAnswer(t *testing.T) {
+	info := libra.TransactionInfo{
+		SignedTransactionHash: sha3Sum([]byte("tx")),
+		StateRootHash:         sha3Sum([]byte("state")),
+		EventRootHash:         sha3Sum([]byte("events")),
+		GasUsed:               7,
+	}
+	// The hashes are fixed size, so they aren't length prefixed
+	expected := saltedSum("TransactionInfo", info.SignedTransactionHash, info.StateRootHash, info.EventRootHash, []byte{7, 0, 0, 0, 0, 0, 0, 0})
+	if !bytes.Equal(info.Hash(), expected) {
+		t.Fatalf("Expected the transaction info hash %x, but was %x", expected, info.Hash())
+	}
+
+	events := []libra.Event{
+		{Address: libra.AccountAddress{1}, Path: []byte{1, 2, 3}, SequenceNo: 2, Data: []byte("data")},
+		{Address: libra.AccountAddress{1}, Path: []byte{1, 2, 3}, SequenceNo: 3, Data: []byte("more")},
+	}
+	var eventHashes [][]byte
+	for _, event := range events {
+		// Address, path and data are length prefixed
+		eventHashes = append(eventHashes, saltedSum("ContractEvent",
+			[]byte{32, 0, 0, 0}, event.Address.Bytes(),
+			[]byte{3, 0, 0, 0}, event.Path,
+			[]byte{byte(event.SequenceNo), 0, 0, 0, 0, 0, 0, 0},
+			[]byte{4, 0, 0, 0}, event.Data))
+	}
+	if !bytes.Equal(events[0].Hash(), eventHashes[0]) {
+		t.Fatalf("Expected the event hash %x, but was %x", eventHashes[0], events[0].Hash())
+	}
+	// Two leaves are a full accumulator, so the root is the parent of both
+	if expected := saltedSum("EventAccumulator", eventHashes[0], eventHashes[1]); !bytes.Equal(libra.EventRootHash(events), expected) {
+		t.Fatalf("Expected the event root hash %x, but was %x", expected, libra.EventRootHash(events))
+	}
+}
+
+// saltedSum returns the SHA3-256 hash of the concatenated parts, prefixed with the hash of the salt, like Libra does.
+func saltedSum(salt string, parts ...[]byte) []byte {
+	saltHash := sha3.Sum256([]byte(salt + "@@$$LIBRA$$@@"))
+	data := saltHash[:]
+	for _, part := range parts {
+		data = append(data, part...)
+	}
+	return sha3Sum(data)
+}
+
+func sha3Sum(b []byte) []byte {
+	h := sha3.Sum256(b)
+	return h[:]
+}
+
+// tamperingServer serves the responses of a libratest.Server after modifying them.
+type tamperingServer struct {
+	*libratest.Server
+	tamper func(res *types.UpdateToLatestLedgerResponse)
+}
+
+// UpdateToLatestLedger implements admission_control.AdmissionControlServer.
+func (ts tamperingServer) UpdateToLatestLedger(ctx context.Context, req *types.UpdateToLatestLedgerRequest) (*types.UpdateToLatestLedgerResponse, error) {
+	res, err := ts.Server.UpdateToLatestLedger(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// The response references the server's state
+	res = proto.Clone(res).(*types.UpdateToLatestLedgerResponse)
+	ts.tamper(res)
+	return res, nil
+}
+
+// TestLedgerStreamInvalidProof tests if a LedgerStream rejects manipulated transactions, events, transaction infos
+// and proofs with a ProofError, and transactions that don't start with the requested version with ErrLedgerGap.
+func TestLedgerStreamInvalidProof(t *testing.T) {
+	s, c, sender := newTestServerAndClient(t, libra.AccountResource{Balance: 100 * libra.Libra})
+	defer s.Close()
+	defer c.Close()
+	for seqNo := uint64(0); seqNo < 3; seqNo++ {
+		if err := c.SendTx(newTestTransfer(t, sender, seqNo, libra.AccountAddress{2}, libra.Libra)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	txList := func(res *types.UpdateToLatestLedgerResponse) *types.TransactionListWithProof {
+		return res.GetResponseItems()[0].GetGetTransactionsResponse().GetTxnListWithProof()
+	}
+	testCases := []struct {
+		name     string
+		tamper   func(res *types.UpdateToLatestLedgerResponse)
+		expected error
+	}{
+		{"valid", func(res *types.UpdateToLatestLedgerResponse) {}, nil},
+		{"transaction", func(res *types.UpdateToLatestLedgerResponse) {
+			txList(res).Transactions[1].SenderSignature = []byte{1}
+		}, libra.ProofError{}},
+		{"event", func(res *types.UpdateToLatestLedgerResponse) {
+			txList(res).EventsForVersions.EventsForVersion[1].Events[0].EventData = []byte{1}
+		}, libra.ProofError{}},
+		{"transaction info", func(res *types.UpdateToLatestLedgerResponse) {
+			txList(res).Infos[1].GasUsed++
+		}, libra.ProofError{}},
+		// The sibling at the bottom is the left sibling of version 1, which isn't computed from the transactions
+		{"proof", func(res *types.UpdateToLatestLedgerResponse) {
+			siblings := txList(res).ProofOfFirstTransaction.NonDefaultSiblings
+			siblings[len(siblings)-1] = make([]byte, 32)
+		}, libra.ProofError{}},
+		{"ledger info", func(res *types.UpdateToLatestLedgerResponse) {
+			res.LedgerInfoWithSigs.LedgerInfo.TransactionAccumulatorHash = make([]byte, 32)
+		}, libra.ProofError{}},
+		{"gap", func(res *types.UpdateToLatestLedgerResponse) {
+			txList(res).FirstTransactionVersion.Value++
+		}, libra.ErrLedgerGap},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			grpcServer := grpc.NewServer()
+			admission_control.RegisterAdmissionControlServer(grpcServer, tamperingServer{Server: s, tamper: tc.tamper})
+			go grpcServer.Serve(lis)
+			defer grpcServer.Stop()
+			tamperedClient, err := libra.NewClient(lis.Addr().String(), time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tamperedClient.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			errDone := errors.New("done")
+			err = tamperedClient.NewLedgerStream(1).Run(ctx, func(tx libra.CommittedTransaction) error {
+				return errDone
+			})
+			switch expected := tc.expected.(type) {
+			case nil:
+				if err != errDone {
+					t.Fatalf("Expected the valid transactions to be handled, but was %v", err)
+				}
+			case libra.ProofError:
+				if _, ok := err.(libra.ProofError); !ok {
+					t.Fatalf("Expected a libra.ProofError, but was %v", err)
+				}
+			default:
+				if err != expected {
+					t.Fatalf("Expected %v, but was %v", expected, err)
+				}
+			}
+		})
+	}
+}
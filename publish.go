@@ -0,0 +1,230 @@
+package libra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// ModuleFileExtension is the file extension of compiled modules that LoadModules(...) looks for in directories.
+const ModuleFileExtension = ".mv"
+
+// LoadModules reads the bytecode of compiled Move modules.
+// Each path is either a module file or a directory, of which all files with ModuleFileExtension are read
+// in the order of their names. The order of the returned modules is the order of the module indexes
+// in VerificationFailure, so modules must come after the modules they depend on.
+func LoadModules(paths ...string) ([][]byte, error) {
+	var result [][]byte
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		files := []string{path}
+		if info.IsDir() {
+			files = nil
+			infos, err := ioutil.ReadDir(path)
+			if err != nil {
+				return nil, err
+			}
+			for _, fileInfo := range infos {
+				if !fileInfo.IsDir() && filepath.Ext(fileInfo.Name()) == ModuleFileExtension {
+					files = append(files, filepath.Join(path, fileInfo.Name()))
+				}
+			}
+		}
+		for _, file := range files {
+			module, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, module)
+		}
+	}
+	return result, nil
+}
+
+// NewPublishTransaction creates a raw transaction with a program that publishes the given modules under the sender's account.
+// The script is executed after the modules are published. It can be a script whose main function just returns.
+func NewPublishTransaction(sender AccountAddress, seqNo uint64, script []byte, modules [][]byte, fee Fee) (RawTransaction, error) {
+	if len(script) == 0 {
+		return RawTransaction{}, errors.New("The script is empty")
+	}
+	if len(modules) == 0 {
+		return RawTransaction{}, errors.New("There are no modules to publish")
+	}
+	for i, module := range modules {
+		if len(module) == 0 {
+			return RawTransaction{}, fmt.Errorf("The module %v is empty", i)
+		}
+	}
+	rawTx := RawTransaction{
+		Sender:     sender,
+		SequenceNo: seqNo,
+		Program: &Program{
+			Code:    script,
+			Modules: modules,
+		},
+	}
+	rawTx.SetFee(fee)
+	return rawTx, nil
+}
+
+// PublishModules sends a transaction that publishes the given modules under the signer's account,
+// created with NewPublishTransaction(...). Use LoadModules(...) for reading compiled module files.
+// The transaction gets the sender's current sequence number like Transfer(...), and a fee that's estimated
+// from recent transactions with the same script, but whose max gas amount at least covers the intrinsic cost
+// of the transaction's size plus the fee policy's margin, see IntrinsicGas(...). The fee can be set via Client.WithFee(...).
+// If the max gas amount doesn't cover the intrinsic cost, or if the modules are too large to be published
+// within MaxTransactionGasUnits, a FeeError is returned before anything is sent.
+//
+// If the VM rejects the program because the script, a module or a dependency failed verification,
+// or because a module with the same name is already published, a PublishError is returned.
+// The sequence number of the sent transaction is returned, which can be used for WaitForTransaction(...).
+func (c Client) PublishModules(ctx context.Context, signer Signer, script []byte, modules [][]byte) (uint64, error) {
+	fee, err := c.publishFee(ctx, SenderOf(signer), script, modules)
+	if err != nil {
+		return 0, err
+	}
+	seqNo, err := c.WithFee(fee).submitScript(ctx, signer, script, 0, func(sender AccountAddress, seqNo uint64, fee Fee) (RawTransaction, error) {
+		return NewPublishTransaction(sender, seqNo, script, modules, fee)
+	})
+	if submitErr, ok := err.(SubmitError); ok {
+		if publishErr, ok := newPublishError(submitErr); ok {
+			return 0, publishErr
+		}
+	}
+	return seqNo, err
+}
+
+// publishFee returns the fee of a transaction that publishes the given modules.
+// The fee that's set via WithFee(...) or estimated from recent transactions with the same script must cover
+// the intrinsic cost of the transaction, which grows with the size of the modules. An estimated max gas amount
+// is raised to the intrinsic cost plus the policy's margin, but a fee that's set is only checked.
+func (c Client) publishFee(ctx context.Context, sender AccountAddress, script []byte, modules [][]byte) (Fee, error) {
+	// The largest values of the varint fields give an upper bound of the raw transaction's size
+	draft, err := NewPublishTransaction(sender, math.MaxUint64, script, modules, Fee{
+		MaxGasAmount: MaxTransactionGasUnits,
+		GasUnitPrice: MaxGasUnitPrice,
+	})
+	if err != nil {
+		return Fee{}, err
+	}
+	draft.ExpirationTime = math.MaxUint64
+	rawTxBytes, err := draft.Bytes()
+	if err != nil {
+		return Fee{}, err
+	}
+	intrinsicGas := IntrinsicGas(len(rawTxBytes))
+
+	fee, err := c.transactionFee(ctx, script)
+	if err != nil {
+		return Fee{}, err
+	}
+	if intrinsicGas > MaxTransactionGasUnits {
+		fee.MaxGasAmount = intrinsicGas
+		return Fee{}, FeeError{
+			Code: types.VMValidationStatusCode_MaxGasUnitsExceedsMaxGasUnitsBound,
+			Fee:  fee,
+		}
+	}
+	if c.fee == nil {
+		minGasAmount := intrinsicGas + intrinsicGas*c.policy().MarginPercent/100
+		if minGasAmount > MaxTransactionGasUnits {
+			minGasAmount = MaxTransactionGasUnits
+		}
+		if fee.MaxGasAmount < minGasAmount {
+			fee.MaxGasAmount = minGasAmount
+		}
+	}
+	if fee.MaxGasAmount < intrinsicGas {
+		return Fee{}, FeeError{
+			Code: types.VMValidationStatusCode_MaxGasUnitsBelowMinTransactionGasUnits,
+			Fee:  fee,
+		}
+	}
+	return fee, nil
+}
+
+// VerificationFailure is the verification failure of a script, a module or a dependency of a published program.
+type VerificationFailure struct {
+	// Kind is SCRIPT, MODULE or DEPENDENCY
+	Kind types.VMVerificationStatus_StatusKind
+	// ModuleIndex is the index of the module in Program.Modules, for Kind MODULE
+	ModuleIndex uint32
+	// Dependency is the ID of the published module the program depends on, for Kind DEPENDENCY
+	Dependency *types.ModuleId
+	ErrorKind  types.VMVerificationErrorKind
+	Message    string
+}
+
+// String formats the failure like "module 1: InvalidSignatureToken (message)".
+func (f VerificationFailure) String() string {
+	var s string
+	switch f.Kind {
+	case types.VMVerificationStatus_SCRIPT:
+		s = "script"
+	case types.VMVerificationStatus_MODULE:
+		s = fmt.Sprintf("module %v", f.ModuleIndex)
+	case types.VMVerificationStatus_DEPENDENCY:
+		s = fmt.Sprintf("dependency 0x%x::%v", f.Dependency.GetAddress(), f.Dependency.GetName())
+	}
+	s += ": " + f.ErrorKind.String()
+	if f.Message != "" {
+		s += " (" + f.Message + ")"
+	}
+	return s
+}
+
+// PublishError is returned when the VM rejects a program that publishes modules.
+type PublishError struct {
+	SubmitError
+	// Failures are the verification failures of the script, modules and dependencies
+	Failures []VerificationFailure
+	// DuplicateModuleName is true if a module with the same name is already published under the sender's account
+	DuplicateModuleName bool
+}
+
+// Error implements the error interface.
+func (e PublishError) Error() string {
+	if e.DuplicateModuleName {
+		return "Publishing the modules failed: A module with the same name is already published (DuplicateModuleName)"
+	}
+	var failures []string
+	for _, f := range e.Failures {
+		failures = append(failures, f.String())
+	}
+	return "Publishing the modules failed: Verification failed for " + strings.Join(failures, ", ")
+}
+
+// newPublishError converts a SubmitError into a PublishError,
+// if its VM status is a verification status list or the runtime status DuplicateModuleName.
+func newPublishError(submitErr SubmitError) (PublishError, bool) {
+	result := PublishError{
+		SubmitError: submitErr,
+	}
+	switch errorType := submitErr.VMStatus.GetErrorType().(type) {
+	case *types.VMStatus_Verification:
+		for _, status := range errorType.Verification.GetStatusList() {
+			result.Failures = append(result.Failures, VerificationFailure{
+				Kind:        status.GetStatusKind(),
+				ModuleIndex: status.GetModuleIdx(),
+				Dependency:  status.GetDependencyId(),
+				ErrorKind:   status.GetErrorKind(),
+				Message:     status.GetMessage(),
+			})
+		}
+		return result, len(result.Failures) > 0
+	case *types.VMStatus_Execution:
+		result.DuplicateModuleName = errorType.Execution.GetRuntimeStatus() == types.RuntimeStatus_DuplicateModuleName
+		return result, result.DuplicateModuleName
+	}
+	return PublishError{}, false
+}
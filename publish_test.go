@@ -0,0 +1,175 @@
+package libra_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestLoadModules tests if libra.LoadModules(...) reads module files and the module files of directories in order.
+func TestLoadModules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "modules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, content := range map[string]string{"b.mv": "b", "a.mv": "a", "c.txt": "c", "d.bin": "d"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	modules, err := libra.LoadModules(filepath.Join(dir, "d.bin"), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, module := range modules {
+		names = append(names, string(module))
+	}
+	if strings.Join(names, ",") != "d,a,b" {
+		t.Fatalf("Expected the modules d, a and b, but was %v", names)
+	}
+	if _, err := libra.LoadModules(filepath.Join(dir, "missing.mv")); err == nil {
+		t.Fatal("Expected an error for a missing file")
+	}
+}
+
+// TestPublishModules tests if modules are published and if rejections are mapped to a libra.PublishError.
+func TestPublishModules(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.SetAccount(sender, libra.AccountResource{Balance: libra.Libra, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	script := []byte{20, 21}
+	modules := [][]byte{{22}, {23}}
+	if _, err := c.PublishModules(context.Background(), signer, script, modules); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := c.GetAccountTransaction(context.Background(), sender, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx, err := libra.RawTransactionFromBytes(tx.Transaction.RawBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rawTx.Program.Modules) != 2 {
+		t.Fatalf("Expected 2 published modules, but got %v", len(rawTx.Program.Modules))
+	}
+
+	_, err = c.PublishModules(context.Background(), signer, script, modules[1:])
+	if publishErr, ok := err.(libra.PublishError); !ok || !publishErr.DuplicateModuleName {
+		t.Fatalf("Expected a PublishError for a duplicate module name, but was %v", err)
+	}
+
+	s.RejectSubmissions(1, &types.VMStatus{
+		ErrorType: &types.VMStatus_Verification{
+			Verification: &types.VMVerificationStatusList{
+				StatusList: []*types.VMVerificationStatus{
+					{
+						StatusKind: types.VMVerificationStatus_MODULE,
+						ModuleIdx:  1,
+						ErrorKind:  types.VMVerificationErrorKind_InvalidSignatureToken,
+					},
+					{
+						StatusKind:   types.VMVerificationStatus_DEPENDENCY,
+						ErrorKind:    types.VMVerificationErrorKind_LookupFailed,
+						DependencyId: &types.ModuleId{Address: make([]byte, 32), Name: "LibraCoin"},
+					},
+				},
+			},
+		},
+	})
+	_, err = c.PublishModules(context.Background(), signer, script, [][]byte{{24}, {25}})
+	publishErr, ok := err.(libra.PublishError)
+	if !ok || len(publishErr.Failures) != 2 {
+		t.Fatalf("Expected a PublishError with 2 verification failures, but was %v", err)
+	}
+	for _, expected := range []string{"module 1: InvalidSignatureToken", "::LibraCoin: LookupFailed"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected the error message to contain %q, but was %q", expected, err)
+		}
+	}
+}
+
+// TestPublishModulesFee tests if the max gas amount of a publish transaction covers the intrinsic cost of its modules
+// and if modules that are too large to be published are rejected before anything is sent.
+func TestPublishModulesFee(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.SetAccount(sender, libra.AccountResource{Balance: libra.Libra, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// The first transaction leads to an estimation of MinTransactionGasUnits for the script
+	script := []byte{20, 21}
+	if _, err := c.PublishModules(context.Background(), signer, script, [][]byte{{22}}); err != nil {
+		t.Fatal(err)
+	}
+	modules := [][]byte{make([]byte, 20000), make([]byte, 5000)}
+	seqNo, err := c.PublishModules(context.Background(), signer, script, modules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := c.GetAccountTransaction(context.Background(), sender, seqNo, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx, err := libra.RawTransactionFromBytes(tx.Transaction.RawBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intrinsicGas := libra.IntrinsicGas(len(tx.Transaction.RawBytes))
+	if expected := intrinsicGas + intrinsicGas*libra.DefaultFeePolicy.MarginPercent/100; rawTx.MaxGasAmount < expected {
+		t.Fatalf("Expected a max gas amount of at least %v, but was %v", expected, rawTx.MaxGasAmount)
+	}
+
+	_, err = c.WithFee(libra.DefaultFeePolicy.Default).PublishModules(context.Background(), signer, script, modules)
+	if feeErr, ok := err.(libra.FeeError); !ok || feeErr.Code != types.VMValidationStatusCode_MaxGasUnitsBelowMinTransactionGasUnits {
+		t.Fatalf("Expected a libra.FeeError with MaxGasUnitsBelowMinTransactionGasUnits, but was %v", err)
+	}
+	_, err = c.PublishModules(context.Background(), signer, script, [][]byte{make([]byte, 200000)})
+	if feeErr, ok := err.(libra.FeeError); !ok || feeErr.Code != types.VMValidationStatusCode_MaxGasUnitsExceedsMaxGasUnitsBound {
+		t.Fatalf("Expected a libra.FeeError with MaxGasUnitsExceedsMaxGasUnitsBound, but was %v", err)
+	}
+	if accRes, _ := s.Account(sender); accRes.SequenceNo != 2 {
+		t.Fatalf("Expected the over-budget transactions not to be sent, but the sequence number was %v", accRes.SequenceNo)
+	}
+}
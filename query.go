@@ -0,0 +1,336 @@
+package libra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/philippgille/libra-sdk-go/internal/canonical"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// ErrTransactionNotFound is returned when a requested transaction isn't committed on the ledger.
+var ErrTransactionNotFound = errors.New("The transaction wasn't found on the ledger")
+
+// TransactionInfo contains the information the ledger stores about a committed transaction.
+type TransactionInfo struct {
+	// SignedTransactionHash is the hash of the signed transaction
+	SignedTransactionHash []byte
+	// StateRootHash is the root hash of the world state after the transaction
+	StateRootHash []byte
+	// EventRootHash is the root hash of the events that were emitted by the transaction
+	EventRootHash []byte
+	// GasUsed is the amount of gas units the transaction used
+	GasUsed uint64
+}
+
+// transactionInfoJSON is the JSON representation of a TransactionInfo.
+type transactionInfoJSON struct {
+	SignedTransactionHash hexBytes `json:"signed_transaction_hash"`
+	StateRootHash         hexBytes `json:"state_root_hash"`
+	EventRootHash         hexBytes `json:"event_root_hash"`
+	GasUsed               uint64   `json:"gas_used,string"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (ti TransactionInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transactionInfoJSON{
+		SignedTransactionHash: ti.SignedTransactionHash,
+		StateRootHash:         ti.StateRootHash,
+		EventRootHash:         ti.EventRootHash,
+		GasUsed:               ti.GasUsed,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ti *TransactionInfo) UnmarshalJSON(data []byte) error {
+	var v transactionInfoJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*ti = TransactionInfo{
+		SignedTransactionHash: v.SignedTransactionHash,
+		StateRootHash:         v.StateRootHash,
+		EventRootHash:         v.EventRootHash,
+		GasUsed:               v.GasUsed,
+	}
+	return nil
+}
+
+func transactionInfoFromProto(info *types.TransactionInfo) TransactionInfo {
+	return TransactionInfo{
+		SignedTransactionHash: info.GetSignedTransactionHash(),
+		StateRootHash:         info.GetStateRootHash(),
+		EventRootHash:         info.GetEventRootHash(),
+		GasUsed:               info.GetGasUsed(),
+	}
+}
+
+// Event is an event that was emitted by a transaction, e.g. for a sent or received payment.
+type Event struct {
+	// Address of the account the event handle belongs to
+	Address AccountAddress
+	// Path of the event handle within the account, see the accesspath package
+	Path []byte
+	// SequenceNo is the sequence number of the event within its event handle
+	SequenceNo uint64
+	// Data of the event
+	Data []byte
+	// TransactionVersion is the version of the transaction that emitted the event
+	TransactionVersion uint64
+}
+
+// eventJSON is the JSON representation of an Event.
+type eventJSON struct {
+	Address            AccountAddress `json:"address"`
+	Path               hexBytes       `json:"path"`
+	SequenceNo         uint64         `json:"sequence_number,string"`
+	Data               hexBytes       `json:"event_data"`
+	TransactionVersion uint64         `json:"transaction_version,string"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{
+		Address:            e.Address,
+		Path:               e.Path,
+		SequenceNo:         e.SequenceNo,
+		Data:               e.Data,
+		TransactionVersion: e.TransactionVersion,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var v eventJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = Event{
+		Address:            v.Address,
+		Path:               v.Path,
+		SequenceNo:         v.SequenceNo,
+		Data:               v.Data,
+		TransactionVersion: v.TransactionVersion,
+	}
+	return nil
+}
+
+// AccessPath returns the access path of the event's event handle.
+func (e Event) AccessPath() *types.AccessPath {
+	return &types.AccessPath{
+		Address: e.Address.Bytes(),
+		Path:    e.Path,
+	}
+}
+
+// PaymentEvent is the data of the sent and received payment events of an account.
+type PaymentEvent struct {
+	Amount Amount
+	// Counterparty is the receiver of a sent payment or the sender of a received payment
+	Counterparty AccountAddress
+}
+
+// PaymentEventFromBytes decodes the data of a sent or received payment event.
+// The data is the amount as little-endian uint64, followed by the length-prefixed address of the counterparty.
+func PaymentEventFromBytes(b []byte) (PaymentEvent, error) {
+	d := canonical.NewDeserializer(b)
+	amount := d.U64()
+	addrBytes := d.Bytes()
+	if err := d.Finish(); err != nil {
+		return PaymentEvent{}, err
+	}
+	addr, err := AccountAddressFromBytes(addrBytes)
+	if err != nil {
+		return PaymentEvent{}, err
+	}
+	return PaymentEvent{
+		Amount:       Amount(amount),
+		Counterparty: addr,
+	}, nil
+}
+
+// ToBytes encodes the payment event into the event data format, the inverse of PaymentEventFromBytes(...).
+func (pe PaymentEvent) ToBytes() []byte {
+	s := canonical.Serializer{}
+	return s.U64(uint64(pe.Amount)).Bytes(pe.Counterparty.Bytes()).Result()
+}
+
+// PaymentEvent decodes the event's data as payment event.
+// It only works for events of the sent and received payment event handles.
+func (e Event) PaymentEvent() (PaymentEvent, error) {
+	return PaymentEventFromBytes(e.Data)
+}
+
+func eventFromProto(event *types.Event, txVersion uint64) Event {
+	// An invalid address leads to the zero address
+	addr, _ := AccountAddressFromBytes(event.GetAccessPath().GetAddress())
+	return Event{
+		Address:            addr,
+		Path:               event.GetAccessPath().GetPath(),
+		SequenceNo:         event.GetSequenceNumber(),
+		Data:               event.GetEventData(),
+		TransactionVersion: txVersion,
+	}
+}
+
+func eventsFromProto(events []*types.Event, txVersion uint64) []Event {
+	var result []Event
+	for _, event := range events {
+		result = append(result, eventFromProto(event, txVersion))
+	}
+	return result
+}
+
+// CommittedTransaction is a transaction that's committed on the ledger.
+type CommittedTransaction struct {
+	// Version of the ledger at which the transaction was committed
+	Version     uint64
+	Transaction Transaction
+	Info        TransactionInfo
+	// Events that were emitted by the transaction.
+	// Only set if they were requested.
+	Events []Event
+}
+
+// committedTransactionJSON is the JSON representation of a CommittedTransaction.
+type committedTransactionJSON struct {
+	Version     uint64          `json:"version,string"`
+	Transaction Transaction     `json:"signed_transaction"`
+	Info        TransactionInfo `json:"transaction_info"`
+	Events      []Event         `json:"events"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (ct CommittedTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(committedTransactionJSON{
+		Version:     ct.Version,
+		Transaction: ct.Transaction,
+		Info:        ct.Info,
+		Events:      ct.Events,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ct *CommittedTransaction) UnmarshalJSON(data []byte) error {
+	var v committedTransactionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*ct = CommittedTransaction{
+		Version:     v.Version,
+		Transaction: v.Transaction,
+		Info:        v.Info,
+		Events:      v.Events,
+	}
+	return nil
+}
+
+// GetAccountTransaction requests the committed transaction of the given sender with the given sequence number.
+// If there's no such transaction on the ledger, ErrTransactionNotFound is returned.
+func (c Client) GetAccountTransaction(ctx context.Context, sender AccountAddress, seqNo uint64, fetchEvents bool) (CommittedTransaction, error) {
+	requestItem := &types.RequestItem{
+		RequestedItems: &types.RequestItem_GetAccountTransactionBySequenceNumberRequest{
+			GetAccountTransactionBySequenceNumberRequest: &types.GetAccountTransactionBySequenceNumberRequest{
+				Account:        sender.Bytes(),
+				SequenceNumber: seqNo,
+				FetchEvents:    fetchEvents,
+			},
+		},
+	}
+	responseItem, _, err := c.requestItem(ctx, requestItem)
+	if err != nil {
+		return CommittedTransaction{}, err
+	}
+	txWithProof := responseItem.GetGetAccountTransactionBySequenceNumberResponse().GetSignedTransactionWithProof()
+	signedTx := txWithProof.GetSignedTransaction()
+	if signedTx == nil {
+		return CommittedTransaction{}, ErrTransactionNotFound
+	}
+	version := txWithProof.GetVersion()
+	return CommittedTransaction{
+		Version:     version,
+		Transaction: transactionFromProto(signedTx),
+		Info:        transactionInfoFromProto(txWithProof.GetProof().GetTransactionInfo()),
+		Events:      eventsFromProto(txWithProof.GetEvents().GetEvents(), version),
+	}, nil
+}
+
+// GetTransactions requests up to limit committed transactions, starting with the given version.
+// Fewer transactions are returned when the end of the ledger is reached.
+func (c Client) GetTransactions(ctx context.Context, start, limit uint64, fetchEvents bool) ([]CommittedTransaction, error) {
+	requestItem := &types.RequestItem{
+		RequestedItems: &types.RequestItem_GetTransactionsRequest{
+			GetTransactionsRequest: &types.GetTransactionsRequest{
+				StartVersion: start,
+				Limit:        limit,
+				FetchEvents:  fetchEvents,
+			},
+		},
+	}
+	responseItem, _, err := c.requestItem(ctx, requestItem)
+	if err != nil {
+		return nil, err
+	}
+	return transactionsFromProto(responseItem.GetGetTransactionsResponse().GetTxnListWithProof(), fetchEvents)
+}
+
+// transactionsFromProto converts a transaction list of a GetTransactionsResponse.
+func transactionsFromProto(txList *types.TransactionListWithProof, fetchEvents bool) ([]CommittedTransaction, error) {
+	signedTxs := txList.GetTransactions()
+	infos := txList.GetInfos()
+	if len(infos) != len(signedTxs) {
+		return nil, errors.New("The number of transaction infos doesn't match the number of transactions")
+	}
+	eventLists := txList.GetEventsForVersions().GetEventsForVersion()
+	if fetchEvents && len(eventLists) != len(signedTxs) {
+		return nil, errors.New("The number of event lists doesn't match the number of transactions")
+	}
+	firstVersion := txList.GetFirstTransactionVersion().GetValue()
+
+	var result []CommittedTransaction
+	for i, signedTx := range signedTxs {
+		version := firstVersion + uint64(i)
+		committedTx := CommittedTransaction{
+			Version:     version,
+			Transaction: transactionFromProto(signedTx),
+			Info:        transactionInfoFromProto(infos[i]),
+		}
+		if fetchEvents {
+			committedTx.Events = eventsFromProto(eventLists[i].GetEvents(), version)
+		}
+		result = append(result, committedTx)
+	}
+	return result, nil
+}
+
+// GetEvents requests up to limit events of the given event handle, starting with the given event sequence number.
+// If ascending is false, the events before the start sequence number are returned, in descending order.
+// Both cases include the event with the start sequence number.
+// Use accesspath.SentEvents(...) and accesspath.ReceivedEvents(...) for an account's payment events.
+func (c Client) GetEvents(ctx context.Context, accessPath *types.AccessPath, start uint64, ascending bool, limit uint64) ([]Event, error) {
+	requestItem := &types.RequestItem{
+		RequestedItems: &types.RequestItem_GetEventsByEventAccessPathRequest{
+			GetEventsByEventAccessPathRequest: &types.GetEventsByEventAccessPathRequest{
+				AccessPath:       accessPath,
+				StartEventSeqNum: start,
+				Ascending:        ascending,
+				Limit:            limit,
+			},
+		},
+	}
+	responseItem, _, err := c.requestItem(ctx, requestItem)
+	if err != nil {
+		return nil, err
+	}
+	return eventsFromResponseItem(responseItem), nil
+}
+
+// eventsFromResponseItem converts the events of a GetEventsByEventAccessPathResponse.
+func eventsFromResponseItem(responseItem *types.ResponseItem) []Event {
+	var result []Event
+	for _, eventWithProof := range responseItem.GetGetEventsByEventAccessPathResponse().GetEventsWithProof() {
+		result = append(result, eventFromProto(eventWithProof.GetEvent(), eventWithProof.GetTransactionVersion()))
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package libra_test
+
+import (
+	"context"
+	"testing"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/libratest"
+)
+
+// TestGetAccountTransaction tests if libra.Client.GetAccountTransaction(...) returns committed transactions
+// and libra.ErrTransactionNotFound for others.
+func TestGetAccountTransaction(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	tx := newTestTx(t, addr, 0)
+	if err := c.SendTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	committedTx, err := c.GetAccountTransaction(context.Background(), addr, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if committedTx.Version != 0 {
+		t.Fatalf("Expected version 0, but was %v", committedTx.Version)
+	}
+	if string(committedTx.Transaction.RawBytes) != string(tx.RawBytes) {
+		t.Fatal("The raw bytes of the committed transaction don't match the sent transaction")
+	}
+	if committedTx.Info.GasUsed != libratest.DefaultGasUsed {
+		t.Fatalf("Expected %v gas used, but was %v", libratest.DefaultGasUsed, committedTx.Info.GasUsed)
+	}
+
+	_, err = c.GetAccountTransaction(context.Background(), addr, 1, false)
+	if err != libra.ErrTransactionNotFound {
+		t.Fatalf("Expected libra.ErrTransactionNotFound, but was %v", err)
+	}
+}
+
+// TestGetTransactions tests if libra.Client.GetTransactions(...) returns the requested range of transactions.
+func TestGetTransactions(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	for seqNo := uint64(0); seqNo < 3; seqNo++ {
+		if err := c.SendTx(newTestTx(t, addr, seqNo)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txs, err := c.GetTransactions(context.Background(), 1, 5, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Expected 2 transactions, but got %v", len(txs))
+	}
+	for i, tx := range txs {
+		if tx.Version != uint64(i+1) {
+			t.Fatalf("Expected version %v, but was %v", i+1, tx.Version)
+		}
+	}
+}
+
+// TestGetEvents tests if libra.Client.GetEvents(...) works for an account without events.
+func TestGetEvents(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	events, err := c.GetEvents(context.Background(), accesspath.SentEvents(addr.Bytes()), 0, true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected no events, but got %v", len(events))
+	}
+}
+
+// TestGetAccountStateNotFound tests if libra.Client.GetAccountState(...) returns libra.ErrAccountNotFound.
+func TestGetAccountStateNotFound(t *testing.T) {
+	s, c, _ := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	_, err := c.GetAccountState(libra.AccountAddress{1}.String())
+	if err != libra.ErrAccountNotFound {
+		t.Fatalf("Expected libra.ErrAccountNotFound, but was %v", err)
+	}
+}
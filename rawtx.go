@@ -0,0 +1,133 @@
+package libra
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// RawTransaction is an unsigned transaction.
+type RawTransaction struct {
+	// Sender's account address
+	Sender AccountAddress
+	// Sequence number of this transaction corresponding to the sender's account
+	SequenceNo uint64
+	// Program is the transaction script to execute.
+	// Either Program or WriteSet is the payload of the transaction.
+	Program *Program
+	// WriteSet is the payload of write set transactions, see NewWriteSetTransaction(...)
+	WriteSet *WriteSet
+	// MaxGasAmount is the maximum number of gas units the sender is willing to spend for this transaction
+	MaxGasAmount uint64
+	// GasUnitPrice is the price to be paid for each gas unit
+	GasUnitPrice Amount
+	// ExpirationTime in seconds since the Unix epoch.
+	// If set to 0, there is no expiration time.
+	ExpirationTime uint64
+}
+
+// Program is a transaction script with its arguments.
+type Program struct {
+	// Code is the bytecode of the script
+	Code []byte
+	// Arguments that are passed to the script's main function
+	Arguments []TransactionArgument
+	// Modules to publish
+	Modules [][]byte
+}
+
+// TransactionArgument is an argument of a transaction script.
+type TransactionArgument struct {
+	Type types.TransactionArgument_ArgType
+	Data []byte
+}
+
+// Fee returns the gas fields of the transaction.
+func (rt RawTransaction) Fee() Fee {
+	return Fee{
+		MaxGasAmount: rt.MaxGasAmount,
+		GasUnitPrice: rt.GasUnitPrice,
+	}
+}
+
+// SetFee sets the gas fields of the transaction.
+func (rt *RawTransaction) SetFee(fee Fee) {
+	rt.MaxGasAmount = fee.MaxGasAmount
+	rt.GasUnitPrice = fee.GasUnitPrice
+}
+
+// Bytes returns the protobuf encoded raw transaction,
+// which is used as Transaction.RawBytes.
+func (rt RawTransaction) Bytes() ([]byte, error) {
+	return proto.Marshal(rt.toProto())
+}
+
+// toProto converts the raw transaction into the RawTransaction of Libra's gRPC API.
+func (rt RawTransaction) toProto() *types.RawTransaction {
+	result := &types.RawTransaction{
+		SenderAccount:  rt.Sender.Bytes(),
+		SequenceNumber: rt.SequenceNo,
+		MaxGasAmount:   rt.MaxGasAmount,
+		GasUnitPrice:   rt.GasUnitPrice.MicroLibra(),
+		ExpirationTime: rt.ExpirationTime,
+	}
+	if rt.Program != nil {
+		result.Payload = &types.RawTransaction_Program{
+			Program: rt.Program.toProto(),
+		}
+	} else if rt.WriteSet != nil {
+		result.Payload = &types.RawTransaction_WriteSet{
+			WriteSet: rt.WriteSet.toProto(),
+		}
+	}
+	return result
+}
+
+// toProto converts the program into the Program of Libra's gRPC API.
+func (p Program) toProto() *types.Program {
+	result := &types.Program{
+		Code:    p.Code,
+		Modules: p.Modules,
+	}
+	for _, arg := range p.Arguments {
+		result.Arguments = append(result.Arguments, &types.TransactionArgument{
+			Type: arg.Type,
+			Data: arg.Data,
+		})
+	}
+	return result
+}
+
+// RawTransactionFromBytes decodes a protobuf encoded raw transaction, e.g. Transaction.RawBytes.
+func RawTransactionFromBytes(b []byte) (RawTransaction, error) {
+	rawTx := types.RawTransaction{}
+	if err := proto.Unmarshal(b, &rawTx); err != nil {
+		return RawTransaction{}, err
+	}
+	sender, err := AccountAddressFromBytes(rawTx.GetSenderAccount())
+	if err != nil {
+		return RawTransaction{}, err
+	}
+	result := RawTransaction{
+		Sender:         sender,
+		SequenceNo:     rawTx.GetSequenceNumber(),
+		MaxGasAmount:   rawTx.GetMaxGasAmount(),
+		GasUnitPrice:   Amount(rawTx.GetGasUnitPrice()),
+		ExpirationTime: rawTx.GetExpirationTime(),
+	}
+	if program := rawTx.GetProgram(); program != nil {
+		result.Program = &Program{
+			Code:    program.GetCode(),
+			Modules: program.GetModules(),
+		}
+		for _, arg := range program.GetArguments() {
+			result.Program.Arguments = append(result.Program.Arguments, TransactionArgument{
+				Type: arg.GetType(),
+				Data: arg.GetData(),
+			})
+		}
+	} else if writeSet := rawTx.GetWriteSet(); writeSet != nil {
+		result.WriteSet = writeSetFromProto(writeSet)
+	}
+	return result, nil
+}
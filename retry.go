@@ -0,0 +1,134 @@
+package libra
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/philippgille/libra-sdk-go/rpc/admission_control"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// RetryPolicy determines how often and when failed requests to a validator node are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 1 are treated as 1, which means no retries.
+	MaxAttempts int
+	// InitialBackoff is the time to wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum time to wait between two attempts
+	MaxBackoff time.Duration
+	// Multiplier is applied to the backoff after each retry
+	Multiplier float64
+}
+
+// DefaultRetryPolicy is the retry policy of clients created with NewClient(...) and NewMultiNodeClient(...).
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// NoRetryPolicy disables retries.
+var NoRetryPolicy = RetryPolicy{
+	MaxAttempts: 1,
+}
+
+// IsTransientError returns true if the error is a gRPC error that's caused by a temporary condition,
+// like an unreachable or overloaded node, so that the same request can succeed later.
+func IsTransientError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRetryableSubmitError returns true if submitting the same signed transaction again can succeed.
+// This is the case for transient gRPC errors (see IsTransientError(...)), a full mempool
+// and a sequence number that's too new, e.g. because the node didn't see the sender's previous transaction yet.
+//
+// Resubmitting the same signed transaction can't lead to a double spend,
+// because a transaction with the same sender and sequence number can only be committed once.
+// Don't sign a new transaction with a new sequence number for retrying a transaction that might have been received.
+func IsRetryableSubmitError(err error) bool {
+	return IsTransientError(err) || classifySubmitError(err) == submitErrRetryable
+}
+
+// WithRetryPolicy returns a copy of the client that uses the given retry policy.
+//
+// Reads (UpdateToLatestLedger) are retried for errors that IsTransientError(...) classifies as transient.
+// Transactions are retried for errors that IsRetryableSubmitError(...) classifies as retryable,
+// by submitting the same signed transaction again.
+func (c Client) WithRetryPolicy(policy RetryPolicy) Client {
+	acc := c.acc
+	if rc, ok := acc.(*retryingClient); ok {
+		acc = rc.acc
+	}
+	c.acc = &retryingClient{
+		acc:    acc,
+		policy: policy,
+	}
+	return c
+}
+
+// retryingClient is an AdmissionControlClient that retries failed requests according to a retry policy.
+type retryingClient struct {
+	acc    admission_control.AdmissionControlClient
+	policy RetryPolicy
+}
+
+// UpdateToLatestLedger implements admission_control.AdmissionControlClient.
+func (rc *retryingClient) UpdateToLatestLedger(ctx context.Context, in *types.UpdateToLatestLedgerRequest, opts ...grpc.CallOption) (*types.UpdateToLatestLedgerResponse, error) {
+	var res *types.UpdateToLatestLedgerResponse
+	err := rc.policy.do(ctx, IsTransientError, func() error {
+		var err error
+		res, err = rc.acc.UpdateToLatestLedger(ctx, in, opts...)
+		return err
+	})
+	return res, err
+}
+
+// SubmitTransaction implements admission_control.AdmissionControlClient.
+func (rc *retryingClient) SubmitTransaction(ctx context.Context, in *admission_control.SubmitTransactionRequest, opts ...grpc.CallOption) (*admission_control.SubmitTransactionResponse, error) {
+	var res *admission_control.SubmitTransactionResponse
+	err := rc.policy.do(ctx, IsRetryableSubmitError, func() error {
+		var err error
+		res, err = rc.acc.SubmitTransaction(ctx, in, opts...)
+		if err != nil {
+			return err
+		}
+		return submitResponseToError(res)
+	})
+	// Rejections are part of the response, not an error of the gRPC call
+	if _, ok := err.(SubmitError); ok {
+		return res, nil
+	}
+	return res, err
+}
+
+// do calls f until it succeeds, returns an error that's not retryable,
+// the maximum number of attempts is reached or the context is done.
+func (p RetryPolicy) do(ctx context.Context, retryable func(error) bool, f func() error) error {
+	backoff := p.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.MaxAttempts || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = time.Duration(float64(backoff) * p.Multiplier)
+		if backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package libra_test
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/rpc/mempool"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+var testRetryPolicy = libra.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     10 * time.Millisecond,
+	Multiplier:     2,
+}
+
+// TestRetryReads tests if reads are retried for transient errors, but not more often than configured.
+func TestRetryReads(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{Balance: 42})
+	defer s.Close()
+	defer c.Close()
+	c = c.WithRetryPolicy(testRetryPolicy)
+
+	s.FailRequests(2, status.Error(codes.Unavailable, "overloaded"))
+	accState, err := c.GetAccountState(addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accState.AccountResource.Balance != 42 {
+		t.Fatalf("Expected balance 42, but was %v", accState.AccountResource.Balance)
+	}
+
+	s.FailRequests(3, status.Error(codes.Unavailable, "overloaded"))
+	if _, err := c.GetAccountState(addr.String()); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected the gRPC status Unavailable, but was %v", err)
+	}
+
+	s.FailRequests(1, status.Error(codes.InvalidArgument, "invalid"))
+	if _, err := c.GetAccountState(addr.String()); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected the gRPC status InvalidArgument, but was %v", err)
+	}
+
+	c = c.WithRetryPolicy(libra.NoRetryPolicy)
+	s.FailRequests(1, status.Error(codes.Unavailable, "overloaded"))
+	if _, err := c.GetAccountState(addr.String()); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected the gRPC status Unavailable, but was %v", err)
+	}
+}
+
+// TestRetrySubmission tests if a transaction is resubmitted after a transient error and only committed once.
+func TestRetrySubmission(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{Balance: 1000000})
+	defer s.Close()
+	defer c.Close()
+	c = c.WithRetryPolicy(testRetryPolicy)
+
+	s.FailRequests(2, status.Error(codes.Unavailable, "overloaded"))
+	if err := c.SendTx(newTestTx(t, addr, 0)); err != nil {
+		t.Fatal(err)
+	}
+	accRes, _ := s.Account(addr)
+	if accRes.SequenceNo != 1 {
+		t.Fatalf("Expected sequence number 1, but was %v", accRes.SequenceNo)
+	}
+
+	// Rejections are returned without retrying
+	err := c.SendTx(newTestTx(t, addr, 0))
+	if !libra.IsSequenceNumberError(err) {
+		t.Fatalf("Expected a sequence number error, but was %v", err)
+	}
+}
+
+// TestIsRetryableSubmitError tests the classification of submission errors,
+// and that no error is both retryable and a sequence number error.
+func TestIsRetryableSubmitError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"unavailable", status.Error(codes.Unavailable, ""), true},
+		{"invalid argument", status.Error(codes.InvalidArgument, ""), false},
+		{"invalid sequence number", libra.SubmitError{
+			MempoolStatus: &mempool.MempoolAddTransactionStatus{Code: mempool.MempoolAddTransactionStatusCode_InvalidSeqNumber},
+		}, false},
+		{"mempool full", libra.SubmitError{
+			MempoolStatus: &mempool.MempoolAddTransactionStatus{Code: mempool.MempoolAddTransactionStatusCode_MempoolIsFull},
+		}, true},
+		{"insufficient balance", libra.SubmitError{
+			MempoolStatus: &mempool.MempoolAddTransactionStatus{Code: mempool.MempoolAddTransactionStatusCode_InsufficientBalance},
+		}, false},
+		{"sequence number too new", libra.SubmitError{
+			VMStatus: &types.VMStatus{ErrorType: &types.VMStatus_Validation{
+				Validation: &types.VMValidationStatus{Code: types.VMValidationStatusCode_SequenceNumberTooNew},
+			}},
+		}, true},
+		{"sequence number too old", libra.SubmitError{
+			VMStatus: &types.VMStatus{ErrorType: &types.VMStatus_Validation{
+				Validation: &types.VMValidationStatus{Code: types.VMValidationStatusCode_SequenceNumberTooOld},
+			}},
+		}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := libra.IsRetryableSubmitError(tc.err); actual != tc.expected {
+				t.Fatalf("Expected %v, but was %v", tc.expected, actual)
+			}
+			if tc.expected && libra.IsSequenceNumberError(tc.err) {
+				t.Fatal("Expected a retryable error not to be a sequence number error")
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package libra
+
+import (
+	"context"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// RotateAuthenticationKey sends a transaction with the rotate_authentication_key script,
+// which changes the authentication key of the signer's account to the one of the given public key.
+// The script must be registered, see RegisterScript(...).
+//
+// The transaction is signed with the account's current key. For accounts that were already rotated before,
+// use an AccountSigner. After the transaction is committed, the account's transactions must be signed
+// with the new key, e.g. with AccountSigner{Signer: newSigner, Address: address}.
+//
+// The fee is estimated, unless it's set via Client.WithFee(...).
+// The sequence number of the sent transaction is returned, which can be used for WaitForTransaction(...).
+// ErrAuthKeyMismatch is returned if the signer's key isn't the account's current one.
+func (c Client) RotateAuthenticationKey(ctx context.Context, signer Signer, newPublicKey ed25519.PublicKey) (uint64, error) {
+	script, err := GetScript(ScriptRotateAuthenticationKey)
+	if err != nil {
+		return 0, err
+	}
+	return c.submitScript(ctx, signer, script.Code, 0, func(sender AccountAddress, seqNo uint64, fee Fee) (RawTransaction, error) {
+		return NewRotateAuthenticationKeyTransaction(sender, seqNo, newPublicKey, fee)
+	})
+}
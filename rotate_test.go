@@ -0,0 +1,94 @@
+package libra_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestRotateAuthenticationKey tests if the authentication key of an account can be rotated
+// and if the account's transactions must be signed with the new key afterwards.
+func TestRotateAuthenticationKey(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptRotateAuthenticationKey,
+		Code: []byte{7, 8, 9},
+	})
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, newPrivateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	addr := libra.SenderOf(signer)
+	newSigner := libra.PrivateKeySigner(newPrivateKey)
+
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.VerifySignatures = true
+	s.SetAccount(addr, libra.AccountResource{Balance: 10 * libra.Libra, AuthKey: addr.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	seqNo, err := c.RotateAuthenticationKey(context.Background(), signer, newSigner.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seqNo != 0 {
+		t.Fatalf("Expected sequence number 0, but was %v", seqNo)
+	}
+	newAuthKey := libra.AccountAddressFromPublicKey(newSigner.PublicKey()).Bytes()
+	accRes, _ := s.Account(addr)
+	if !bytes.Equal(accRes.AuthKey, newAuthKey) {
+		t.Fatalf("Expected auth key %x, but was %x", newAuthKey, accRes.AuthKey)
+	}
+
+	// The old key isn't valid anymore
+	if _, err := c.RotateAuthenticationKey(context.Background(), signer, signer.PublicKey()); err != libra.ErrAuthKeyMismatch {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAuthKeyMismatch, err)
+	}
+	tx := newTestTransfer(t, addr, 1, libra.AccountAddress{2}, libra.Libra)
+	rawTx, err := libra.RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err = rawTx.Sign(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SendTx(tx)
+	if submitErr, ok := err.(libra.SubmitError); !ok || submitErr.ValidationStatus() != types.VMValidationStatusCode_InvalidAuthKey {
+		t.Fatalf("Expected a SubmitError with validation status InvalidAuthKey, but was %v", err)
+	}
+
+	// Signing with the new key for the account's address
+	accSigner := libra.AccountSigner{Signer: newSigner, Address: addr}
+	if libra.SenderOf(accSigner) != addr {
+		t.Fatalf("Expected sender %v, but was %v", addr, libra.SenderOf(accSigner))
+	}
+	if libra.SenderOf(&accSigner) != addr {
+		t.Fatalf("Expected sender %v for a pointer to the AccountSigner, but was %v", addr, libra.SenderOf(&accSigner))
+	}
+	tx, err = rawTx.Sign(accSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SendTx(tx); err != nil {
+		t.Fatal(err)
+	}
+}
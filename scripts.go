@@ -0,0 +1,186 @@
+package libra
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// Names of Libra's standard transaction scripts
+const (
+	ScriptPeerToPeerTransfer      = "peer_to_peer_transfer"
+	ScriptMint                    = "mint"
+	ScriptRotateAuthenticationKey = "rotate_authentication_key"
+	ScriptCreateAccount           = "create_account"
+)
+
+// ScriptFileExtension is the file extension of compiled scripts that LoadScripts(...) looks for.
+const ScriptFileExtension = ".mv"
+
+// standardScriptArgTypes are the argument types of Libra's standard transaction scripts.
+var standardScriptArgTypes = map[string][]types.TransactionArgument_ArgType{
+	ScriptPeerToPeerTransfer:      {types.TransactionArgument_ADDRESS, types.TransactionArgument_U64},
+	ScriptMint:                    {types.TransactionArgument_ADDRESS, types.TransactionArgument_U64},
+	ScriptRotateAuthenticationKey: {types.TransactionArgument_BYTEARRAY},
+	ScriptCreateAccount:           {types.TransactionArgument_ADDRESS, types.TransactionArgument_U64},
+}
+
+// Script is a compiled transaction script.
+type Script struct {
+	Name string
+	// Code is the bytecode of the script
+	Code []byte
+	// ArgTypes are the types of the arguments of the script's main function
+	ArgTypes []types.TransactionArgument_ArgType
+}
+
+// Registered scripts by name
+var (
+	scriptsLock sync.RWMutex
+	scripts     = map[string]Script{}
+)
+
+// RegisterScript registers a compiled script, so that it can be used by the SDK's transaction builders
+// like NewTransferTransaction(...).
+//
+// The SDK doesn't contain the bytecode of Libra's standard transaction scripts,
+// because it must match the Libra version of the network that's used.
+// Compile them with the Libra repository that the network runs and register them,
+// either with this function or with LoadScripts(...).
+// For the standard scripts, the argument types can be omitted.
+func RegisterScript(script Script) {
+	if script.ArgTypes == nil {
+		script.ArgTypes = standardScriptArgTypes[script.Name]
+	}
+	scriptsLock.Lock()
+	defer scriptsLock.Unlock()
+	scripts[script.Name] = script
+}
+
+// LoadScripts registers the compiled standard transaction scripts that are found in the given directory.
+// The file names must be the script names with ScriptFileExtension, e.g. "peer_to_peer_transfer.mv".
+// Missing files are skipped. The names of the registered scripts are returned.
+func LoadScripts(dir string) ([]string, error) {
+	var loaded []string
+	for _, name := range []string{ScriptPeerToPeerTransfer, ScriptMint, ScriptRotateAuthenticationKey, ScriptCreateAccount} {
+		code, err := ioutil.ReadFile(filepath.Join(dir, name+ScriptFileExtension))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return loaded, err
+		}
+		RegisterScript(Script{
+			Name: name,
+			Code: code,
+		})
+		loaded = append(loaded, name)
+	}
+	return loaded, nil
+}
+
+// GetScript returns the registered script with the given name.
+func GetScript(name string) (Script, error) {
+	scriptsLock.RLock()
+	defer scriptsLock.RUnlock()
+	script, ok := scripts[name]
+	if !ok {
+		return Script{}, fmt.Errorf("The script %q isn't registered. See libra.RegisterScript(...) and libra.LoadScripts(...)", name)
+	}
+	return script, nil
+}
+
+// Hash returns the SHA3-256 hash of the script's bytecode, which identifies the script.
+func (s Script) Hash() []byte {
+	return hashing.SHA3(s.Code)
+}
+
+// ScriptByHash returns the registered script with the given bytecode hash, see Script.Hash().
+// It's used for recognizing the scripts of decoded transactions.
+func ScriptByHash(hash []byte) (Script, bool) {
+	scriptsLock.RLock()
+	defer scriptsLock.RUnlock()
+	for _, script := range scripts {
+		if bytes.Equal(script.Hash(), hash) {
+			return script, true
+		}
+	}
+	return Script{}, false
+}
+
+// NewTransferTransaction creates a raw transaction with the peer-to-peer transfer script,
+// which transfers the given amount from the sender to the receiver.
+// The script must be registered, see RegisterScript(...).
+func NewTransferTransaction(sender AccountAddress, seqNo uint64, receiver AccountAddress, amount Amount, fee Fee) (RawTransaction, error) {
+	return newAddressAmountTransaction(ScriptPeerToPeerTransfer, sender, seqNo, receiver, amount, fee)
+}
+
+// NewCreateAccountTransaction creates a raw transaction with the create_account script,
+// which creates the account with the given address and transfers the initial amount from the sender to it.
+// The script must be registered, see RegisterScript(...).
+func NewCreateAccountTransaction(sender AccountAddress, seqNo uint64, newAddr AccountAddress, initialAmount Amount, fee Fee) (RawTransaction, error) {
+	return newAddressAmountTransaction(ScriptCreateAccount, sender, seqNo, newAddr, initialAmount, fee)
+}
+
+// NewMintTransaction creates a raw transaction with the mint script,
+// which mints the given amount of new Libra Coins for the receiver and creates the receiver's account if it doesn't exist.
+// Only the association account (AssociationAddress) can send it.
+// The script must be registered, see RegisterScript(...).
+func NewMintTransaction(sender AccountAddress, seqNo uint64, receiver AccountAddress, amount Amount, fee Fee) (RawTransaction, error) {
+	return newAddressAmountTransaction(ScriptMint, sender, seqNo, receiver, amount, fee)
+}
+
+// newAddressAmountTransaction creates a raw transaction with a script that takes an address and an amount as arguments.
+func newAddressAmountTransaction(scriptName string, sender AccountAddress, seqNo uint64, addr AccountAddress, amount Amount, fee Fee) (RawTransaction, error) {
+	return NewScriptTransaction(sender, seqNo, scriptName, []TransactionArgument{AddressArg(addr), U64Arg(amount.MicroLibra())}, fee)
+}
+
+// NewRotateAuthenticationKeyTransaction creates a raw transaction with the rotate_authentication_key script,
+// which changes the sender's authentication key to the one of the given public key.
+// Afterwards the sender's transactions must be signed with the corresponding private key.
+// The script must be registered, see RegisterScript(...).
+func NewRotateAuthenticationKeyTransaction(sender AccountAddress, seqNo uint64, newPublicKey ed25519.PublicKey, fee Fee) (RawTransaction, error) {
+	if len(newPublicKey) != ed25519.PublicKeySize {
+		return RawTransaction{}, errors.New("Invalid public key length")
+	}
+	return NewScriptTransaction(sender, seqNo, ScriptRotateAuthenticationKey, []TransactionArgument{BytesArg(AccountAddressFromPublicKey(newPublicKey).Bytes())}, fee)
+}
+
+// NewScriptTransaction creates a raw transaction with the registered script with the given name and the arguments,
+// e.g. for custom scripts. The arguments are checked against the script's argument types, see Script.CheckArguments(...).
+// Create the arguments with U64Arg(...), AddressArg(...), StringArg(...) and BytesArg(...).
+func NewScriptTransaction(sender AccountAddress, seqNo uint64, scriptName string, args []TransactionArgument, fee Fee) (RawTransaction, error) {
+	script, err := GetScript(scriptName)
+	if err != nil {
+		return RawTransaction{}, err
+	}
+	if err := script.CheckArguments(args); err != nil {
+		return RawTransaction{}, err
+	}
+	rawTx := RawTransaction{
+		Sender:     sender,
+		SequenceNo: seqNo,
+		Program: &Program{
+			Code:      script.Code,
+			Arguments: args,
+		},
+	}
+	rawTx.SetFee(fee)
+	return rawTx, nil
+}
+
+// encodeU64 encodes a uint64 as little-endian byte slice, which is the data of U64 transaction arguments.
+func encodeU64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b
+}
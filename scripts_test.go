@@ -0,0 +1,52 @@
+package libra_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestLoadScripts tests if libra.LoadScripts(...) registers the scripts of a directory
+// and if libra.NewTransferTransaction(...) uses the registered script.
+func TestLoadScripts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scripts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	code := []byte{1, 2, 3}
+	err = ioutil.WriteFile(filepath.Join(dir, libra.ScriptPeerToPeerTransfer+libra.ScriptFileExtension), code, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := libra.LoadScripts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded[0] != libra.ScriptPeerToPeerTransfer {
+		t.Fatalf("Expected only %v to be loaded, but was %v", libra.ScriptPeerToPeerTransfer, loaded)
+	}
+	script, err := libra.GetScript(libra.ScriptPeerToPeerTransfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(script.ArgTypes) != 2 || script.ArgTypes[0] != types.TransactionArgument_ADDRESS {
+		t.Fatalf("Expected the argument types of the standard script, but was %v", script.ArgTypes)
+	}
+
+	rawTx, err := libra.NewTransferTransaction(libra.AccountAddress{1}, 0, libra.AccountAddress{2}, 5*libra.Libra, libra.DefaultFeePolicy.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rawTx.Program.Code) != string(code) {
+		t.Fatalf("Expected code %x, but was %x", code, rawTx.Program.Code)
+	}
+	if _, err := rawTx.Hash(); err != nil {
+		t.Fatal(err)
+	}
+}
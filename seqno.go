@@ -0,0 +1,218 @@
+package libra
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// SequenceManager allocates sequence numbers for the transactions of one or more senders.
+// It reserves sequence numbers locally, so that multiple transactions of the same sender
+// can be sent concurrently without fetching the sequence number from the ledger before each transaction.
+// It's safe for concurrent use.
+//
+// The usage is:
+//
+//  1. Reserve(...) a sequence number and use it for a transaction
+//  2. Send the transaction
+//  3. Report the result of sending the transaction via Done(...)
+//
+// If a transaction with a reserved sequence number isn't sent, the number must be handed back via Release(...).
+type SequenceManager struct {
+	c        Client
+	lock     sync.Mutex
+	accounts map[AccountAddress]*accountSeq
+}
+
+// accountSeq is the sequence number state of one sender.
+type accountSeq struct {
+	lock sync.Mutex
+	// synced is false until the sequence number was fetched from the ledger
+	synced bool
+	// next is the lowest sequence number that was never reserved
+	next uint64
+	// pending are the reserved sequence numbers whose transactions weren't reported as done yet
+	pending map[uint64]struct{}
+	// released are sequence numbers below next that were released and not reserved again yet
+	released []uint64
+}
+
+// NewSequenceManager creates a new SequenceManager.
+// The client is used to fetch the sequence numbers of the senders from the ledger.
+func NewSequenceManager(c Client) *SequenceManager {
+	return &SequenceManager{
+		c:        c,
+		accounts: make(map[AccountAddress]*accountSeq),
+	}
+}
+
+// WithSequenceManager returns a copy of the client whose transaction helpers, like CreateAccount(...),
+// RotateAuthenticationKey(...) and PublishModules(...), allocate sequence numbers via the given SequenceManager
+// instead of using the sender's sequence number on the ledger.
+// This allows sending multiple transactions of the same sender concurrently, e.g. creating accounts from one treasury account.
+// The SequenceManager can be shared with other clients.
+func (c Client) WithSequenceManager(sm *SequenceManager) Client {
+	c.seqNos = sm
+	return c
+}
+
+func (sm *SequenceManager) account(sender AccountAddress) *accountSeq {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+	acc, ok := sm.accounts[sender]
+	if !ok {
+		acc = &accountSeq{
+			pending: make(map[uint64]struct{}),
+		}
+		sm.accounts[sender] = acc
+	}
+	return acc
+}
+
+// Reserve reserves a sequence number for a transaction of the given sender.
+// Released sequence numbers are reserved again first, so that gaps are filled.
+// On the first call for a sender, its sequence number is fetched from the ledger.
+func (sm *SequenceManager) Reserve(ctx context.Context, sender AccountAddress) (uint64, error) {
+	acc := sm.account(sender)
+	acc.lock.Lock()
+	synced := acc.synced
+	acc.lock.Unlock()
+	if !synced {
+		ledgerSeqNo, err := sm.fetch(ctx, sender)
+		if err != nil {
+			return 0, err
+		}
+		acc.lock.Lock()
+		acc.advance(ledgerSeqNo)
+		acc.lock.Unlock()
+	}
+
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	var seqNo uint64
+	if len(acc.released) > 0 {
+		seqNo = acc.released[0]
+		acc.released = acc.released[1:]
+	} else {
+		seqNo = acc.next
+		acc.next++
+	}
+	acc.pending[seqNo] = struct{}{}
+	return seqNo, nil
+}
+
+// Release hands back a reserved sequence number whose transaction wasn't sent.
+// It will be reserved again by the next call to Reserve(...).
+func (sm *SequenceManager) Release(sender AccountAddress, seqNo uint64) {
+	acc := sm.account(sender)
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	acc.release(seqNo)
+}
+
+func (acc *accountSeq) release(seqNo uint64) {
+	if _, ok := acc.pending[seqNo]; !ok {
+		return
+	}
+	delete(acc.pending, seqNo)
+	acc.released = append(acc.released, seqNo)
+	sort.Slice(acc.released, func(i, j int) bool { return acc.released[i] < acc.released[j] })
+}
+
+// Done reports the result of sending the transaction with the given sequence number,
+// e.g. the error returned by Client.SendTx(...).
+//
+//  - If err is nil, the sequence number is considered used.
+//  - If err is a sequence number error (see IsSequenceNumberError(...)), the sequence number was already used
+//    by another transaction of the sender, so the local state is out of sync with the ledger.
+//    The sequence number is fetched from the ledger again and the error of fetching it is returned.
+//    Sequence numbers below it aren't reserved again, but the reservations of other transactions are kept.
+//  - If err is any other SubmitError, the transaction was rejected by the node and the sequence number is released.
+//  - For other errors, e.g. a gRPC Unavailable or DeadlineExceeded error, the node might have accepted the
+//    transaction, so the sequence number is kept as reserved. Once it's known that the transaction wasn't
+//    committed, e.g. via Client.WaitForTransaction(...), call Release(...) or Resync(...).
+func (sm *SequenceManager) Done(ctx context.Context, sender AccountAddress, seqNo uint64, err error) error {
+	acc := sm.account(sender)
+	acc.lock.Lock()
+	switch {
+	case err == nil:
+		delete(acc.pending, seqNo)
+	case IsSequenceNumberError(err):
+		delete(acc.pending, seqNo)
+	default:
+		if _, ok := err.(SubmitError); ok {
+			acc.release(seqNo)
+		}
+	}
+	acc.lock.Unlock()
+	if !IsSequenceNumberError(err) {
+		return nil
+	}
+
+	// Fetching without holding the lock doesn't block reservations.
+	// The ledger's sequence number only grows, so applying an outdated one later doesn't undo a newer one.
+	ledgerSeqNo, err := sm.fetch(ctx, sender)
+	if err != nil {
+		return err
+	}
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	acc.advance(ledgerSeqNo)
+	return nil
+}
+
+// Resync discards the local state of the given sender and fetches its sequence number from the ledger.
+// Transactions that are reserved but not done yet should be considered failed.
+func (sm *SequenceManager) Resync(ctx context.Context, sender AccountAddress) error {
+	ledgerSeqNo, err := sm.fetch(ctx, sender)
+	if err != nil {
+		return err
+	}
+	acc := sm.account(sender)
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	acc.next = ledgerSeqNo
+	acc.pending = make(map[uint64]struct{})
+	acc.released = nil
+	acc.synced = true
+	return nil
+}
+
+// Gaps returns the released sequence numbers of the given sender that weren't reserved again yet.
+// Transactions with higher sequence numbers can't be executed until the gaps are filled,
+// either by reserving the numbers again or by calling Resync(...).
+func (sm *SequenceManager) Gaps(sender AccountAddress) []uint64 {
+	acc := sm.account(sender)
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	result := make([]uint64, len(acc.released))
+	copy(result, acc.released)
+	return result
+}
+
+// fetch requests the sequence number of the sender from the ledger.
+func (sm *SequenceManager) fetch(ctx context.Context, sender AccountAddress) (uint64, error) {
+	accState, err := sm.c.GetAccountStateContext(ctx, sender.String())
+	if err != nil {
+		return 0, err
+	}
+	return accState.AccountResource.SequenceNo, nil
+}
+
+// advance updates the local state with the sequence number of the sender on the ledger.
+// Sequence numbers below it are used, so released ones are dropped and the next one is at least the ledger's.
+// Reservations are kept, because their transactions might already be in the mempool.
+// The caller must hold the lock of acc.
+func (acc *accountSeq) advance(ledgerSeqNo uint64) {
+	acc.synced = true
+	if ledgerSeqNo > acc.next {
+		acc.next = ledgerSeqNo
+	}
+	released := acc.released[:0]
+	for _, seqNo := range acc.released {
+		if seqNo >= ledgerSeqNo {
+			released = append(released, seqNo)
+		}
+	}
+	acc.released = released
+}
@@ -0,0 +1,242 @@
+package libra_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// newTestServerAndClient starts a fake validator node with one account and connects a client to it.
+func newTestServerAndClient(t *testing.T, accRes libra.AccountResource) (*libratest.Server, libra.Client, libra.AccountAddress) {
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := libra.ParseAccountAddress(testAcc1AuthKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accRes.AuthKey = addr.Bytes()
+	s.SetAccount(addr, accRes)
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		s.Close()
+		t.Fatal(err)
+	}
+	return s, c, addr
+}
+
+// newTestTx creates an unsigned transaction without payload.
+func newTestTx(t *testing.T, sender libra.AccountAddress, seqNo uint64) libra.Transaction {
+	rawTxBytes, err := proto.Marshal(&types.RawTransaction{
+		SenderAccount:  sender.Bytes(),
+		SequenceNumber: seqNo,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return libra.Transaction{
+		RawBytes: rawTxBytes,
+	}
+}
+
+// TestSequenceManager tests if the libra.SequenceManager reserves, releases and resyncs sequence numbers correctly.
+func TestSequenceManager(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{SequenceNo: 5})
+	defer s.Close()
+	defer c.Close()
+
+	sm := libra.NewSequenceManager(c)
+	for _, expected := range []uint64{5, 6, 7} {
+		seqNo, err := sm.Reserve(context.Background(), addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seqNo != expected {
+			t.Fatalf("Expected %v, but was %v", expected, seqNo)
+		}
+	}
+
+	// Releasing leads to a gap, which gets filled by the next reservation
+	sm.Release(addr, 6)
+	if gaps := sm.Gaps(addr); len(gaps) != 1 || gaps[0] != 6 {
+		t.Fatalf("Expected gaps [6], but was %v", gaps)
+	}
+	seqNo, err := sm.Reserve(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seqNo != 6 {
+		t.Fatalf("Expected 6, but was %v", seqNo)
+	}
+	if gaps := sm.Gaps(addr); len(gaps) != 0 {
+		t.Fatalf("Expected no gaps, but was %v", gaps)
+	}
+
+	// Send the transactions, 7 is sent first and gets executed after 5 and 6
+	for _, seqNo := range []uint64{7, 5, 6} {
+		err = c.SendTx(newTestTx(t, addr, seqNo))
+		if err := sm.Done(context.Background(), addr, seqNo, err); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if accRes, _ := s.Account(addr); accRes.SequenceNo != 8 {
+		t.Fatalf("Expected the sequence number on the ledger to be 8, but was %v", accRes.SequenceNo)
+	}
+
+	// Another sender uses the account, so the local state is out of sync
+	err = c.SendTx(newTestTx(t, addr, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seqNo, err = sm.Reserve(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seqNo != 8 {
+		t.Fatalf("Expected 8, but was %v", seqNo)
+	}
+	// A concurrent transaction reserved the next sequence number
+	concurrentSeqNo, err := sm.Reserve(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SendTx(newTestTx(t, addr, seqNo))
+	if !libra.IsSequenceNumberError(err) {
+		t.Fatalf("Expected a sequence number error, but was %v", err)
+	}
+	if err := sm.Done(context.Background(), addr, seqNo, err); err != nil {
+		t.Fatal(err)
+	}
+	// The concurrent transaction's sequence number isn't reserved again
+	seqNo, err = sm.Reserve(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seqNo != 10 {
+		t.Fatalf("Expected 10 after resyncing, but was %v", seqNo)
+	}
+	for _, seqNo := range []uint64{concurrentSeqNo, seqNo} {
+		err = c.SendTx(newTestTx(t, addr, seqNo))
+		if err := sm.Done(context.Background(), addr, seqNo, err); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if accRes, _ := s.Account(addr); accRes.SequenceNo != 11 {
+		t.Fatalf("Expected the sequence number on the ledger to be 11, but was %v", accRes.SequenceNo)
+	}
+
+	// Resync discards the local state
+	if _, err := sm.Reserve(context.Background(), addr); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.Resync(context.Background(), addr); err != nil {
+		t.Fatal(err)
+	}
+	if seqNo, err = sm.Reserve(context.Background(), addr); err != nil {
+		t.Fatal(err)
+	} else if seqNo != 11 {
+		t.Fatalf("Expected 11 after resyncing, but was %v", seqNo)
+	}
+
+	// Fetching the sequence number is bound to the context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sm.Resync(ctx, addr); err == nil {
+		t.Fatal("Expected an error for a canceled context")
+	}
+}
+
+// TestSequenceManagerDoneErrors tests if sequence numbers are only released when the node rejected the transaction,
+// and kept as reserved for transport errors, after which the node might still have accepted the transaction.
+func TestSequenceManagerDoneErrors(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	sm := libra.NewSequenceManager(c)
+	seqNo, err := sm.Reserve(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.FailRequests(libra.DefaultRetryPolicy.MaxAttempts, status.Error(codes.Unavailable, "overloaded"))
+	err = c.SendTx(newTestTx(t, addr, seqNo))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected an Unavailable error, but was %v", err)
+	}
+	if err := sm.Done(context.Background(), addr, seqNo, err); err != nil {
+		t.Fatal(err)
+	}
+	if gaps := sm.Gaps(addr); len(gaps) != 0 {
+		t.Fatalf("Expected no gaps after a transport error, but was %v", gaps)
+	}
+	if seqNo, err = sm.Reserve(context.Background(), addr); err != nil {
+		t.Fatal(err)
+	} else if seqNo != 1 {
+		t.Fatalf("Expected 1, but was %v", seqNo)
+	}
+
+	s.RejectSubmissions(1, &types.VMStatus{
+		ErrorType: &types.VMStatus_Validation{
+			Validation: &types.VMValidationStatus{Code: types.VMValidationStatusCode_InvalidSignature},
+		},
+	})
+	err = c.SendTx(newTestTx(t, addr, seqNo))
+	if _, ok := err.(libra.SubmitError); !ok {
+		t.Fatalf("Expected a libra.SubmitError, but was %v", err)
+	}
+	if err := sm.Done(context.Background(), addr, seqNo, err); err != nil {
+		t.Fatal(err)
+	}
+	if gaps := sm.Gaps(addr); len(gaps) != 1 || gaps[0] != 1 {
+		t.Fatalf("Expected gaps [1] after a rejection, but was %v", gaps)
+	}
+}
+
+// TestSequenceManagerConcurrency tests if concurrent reservations lead to unique sequence numbers.
+func TestSequenceManagerConcurrency(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	sm := libra.NewSequenceManager(c)
+	const count = 100
+	seqNos := make(chan uint64, count)
+	wg := sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seqNo, err := sm.Reserve(context.Background(), addr)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			seqNos <- seqNo
+		}()
+	}
+	wg.Wait()
+	close(seqNos)
+
+	seen := make(map[uint64]bool, count)
+	for seqNo := range seqNos {
+		if seen[seqNo] {
+			t.Fatalf("Sequence number %v was reserved twice", seqNo)
+		}
+		seen[seqNo] = true
+	}
+	for i := uint64(0); i < count; i++ {
+		if !seen[i] {
+			t.Fatalf("Sequence number %v wasn't reserved", i)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package libra
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/philippgille/libra-sdk-go/internal/canonical"
+	"github.com/philippgille/libra-sdk-go/internal/hashing"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// Variant indexes of the payloads in Libra's canonical serialization of a raw transaction.
+const (
+	payloadProgram  uint32 = 0
+	payloadWriteSet uint32 = 1
+)
+
+// Hash returns the hash of the raw transaction, which is what the sender signs.
+// It's the salted SHA3-256 hash of the raw transaction in Libra's canonical serialization.
+func (rt RawTransaction) Hash() ([]byte, error) {
+	s := &canonical.Serializer{}
+	if err := rt.serialize(s); err != nil {
+		return nil, err
+	}
+	return hashing.Sum(hashing.RawTransactionSalt, s.Result()), nil
+}
+
+// serialize writes the raw transaction in Libra's canonical serialization.
+func (rt RawTransaction) serialize(s *canonical.Serializer) error {
+	s.Bytes(rt.Sender.Bytes()).U64(rt.SequenceNo)
+	switch {
+	case rt.Program != nil && rt.WriteSet != nil:
+		return errors.New("The raw transaction has a program and a write set as payload")
+	case rt.Program != nil:
+		s.U32(payloadProgram)
+		if err := rt.Program.serialize(s); err != nil {
+			return err
+		}
+	case rt.WriteSet != nil:
+		s.U32(payloadWriteSet)
+		if err := rt.WriteSet.serialize(s); err != nil {
+			return err
+		}
+	default:
+		return errors.New("The raw transaction doesn't have a payload")
+	}
+	s.U64(rt.MaxGasAmount).U64(rt.GasUnitPrice.MicroLibra()).U64(rt.ExpirationTime)
+	return nil
+}
+
+// Hash returns the hash of the signed transaction, which is stored in the TransactionInfo of a committed transaction.
+// It's the salted SHA3-256 hash of the signed transaction in Libra's canonical serialization.
+func (tx Transaction) Hash() ([]byte, error) {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return nil, err
+	}
+	s := &canonical.Serializer{}
+	if err := rawTx.serialize(s); err != nil {
+		return nil, err
+	}
+	s.Bytes(tx.SenderPubKey).Bytes(tx.SenderSig)
+	return hashing.Sum(hashing.SignedTransactionSalt, s.Result()), nil
+}
+
+// serialize writes the program in Libra's canonical serialization.
+func (p Program) serialize(s *canonical.Serializer) error {
+	s.Bytes(p.Code)
+	s.U32(uint32(len(p.Arguments)))
+	for _, arg := range p.Arguments {
+		// The canonical serialization of the argument types has the same variant indexes as the protobuf enum.
+		s.U32(uint32(arg.Type))
+		if arg.Type == types.TransactionArgument_U64 {
+			// The protobuf data of U64 arguments is already the fixed size little-endian encoding
+			if len(arg.Data) != 8 {
+				return errors.New("Invalid data length of U64 transaction argument")
+			}
+			s.Raw(arg.Data)
+		} else {
+			s.Bytes(arg.Data)
+		}
+	}
+	s.U32(uint32(len(p.Modules)))
+	for _, module := range p.Modules {
+		s.Bytes(module)
+	}
+	return nil
+}
+
+// Sign signs the raw transaction with the given signer.
+// Use PrivateKeySigner for an in-memory ed25519 private key.
+// The returned transaction can be sent with Client.SendTx(...).
+func (rt RawTransaction) Sign(signer Signer) (Transaction, error) {
+	hash, err := rt.Hash()
+	if err != nil {
+		return Transaction{}, err
+	}
+	rawBytes, err := rt.Bytes()
+	if err != nil {
+		return Transaction{}, err
+	}
+	signature, err := signer.Sign(hash)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return Transaction{
+		RawBytes:     rawBytes,
+		SenderPubKey: signer.PublicKey(),
+		SenderSig:    signature,
+	}, nil
+}
+
+// AccountAddressFromPublicKey derives the account address from an ed25519 public key.
+// It's the SHA3-256 hash of the public key.
+func AccountAddressFromPublicKey(publicKey ed25519.PublicKey) AccountAddress {
+	var result AccountAddress
+	copy(result[:], hashing.SHA3(publicKey))
+	return result
+}
+
+// ErrAuthKeyMismatch is returned when the public key of a signed transaction doesn't derive to the sender's authentication key.
+var ErrAuthKeyMismatch = errors.New("The public key doesn't match the sender's authentication key")
+
+// Verify checks the signature of the transaction and if the public key belongs to the sender,
+// e.g. for transactions that were returned by a query or before sending a transaction with Client.SendTx(...).
+// It recomputes the hash of the raw transaction and returns ErrInvalidSignature if the signature isn't valid for it.
+//
+// The sender's authentication key is assumed to be the account address, which is the case until the key is rotated.
+// ErrAuthKeyMismatch is returned if the public key doesn't derive to it.
+// For transactions of accounts with a rotated key, e.g. fetched ones, use VerifyAuthKey(...)
+// or Client.VerifyTransaction(...), which reads the authentication key from the ledger.
+func (tx Transaction) Verify() error {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return err
+	}
+	return tx.verify(rawTx, rawTx.Sender.Bytes())
+}
+
+// VerifyAuthKey is like Verify(), but checks if the public key derives to the given authentication key
+// instead of the sender's account address, e.g. the AuthKey of the sender's AccountResource.
+func (tx Transaction) VerifyAuthKey(authKey []byte) error {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return err
+	}
+	return tx.verify(rawTx, authKey)
+}
+
+// VerifyTransaction is like Transaction.VerifyAuthKey(...) with the AuthKey of the sender's AccountResource,
+// which is requested from the validator node. ErrAccountNotFound is returned if the sender doesn't exist.
+func (c Client) VerifyTransaction(ctx context.Context, tx Transaction) error {
+	rawTx, err := RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		return err
+	}
+	accState, err := c.GetAccountStateContext(ctx, rawTx.Sender.String())
+	if err != nil {
+		return err
+	}
+	return tx.verify(rawTx, accState.AccountResource.AuthKey)
+}
+
+func (tx Transaction) verify(rawTx RawTransaction, authKey []byte) error {
+	hash, err := rawTx.Hash()
+	if err != nil {
+		return err
+	}
+	if len(tx.SenderPubKey) != ed25519.PublicKeySize || !ed25519.Verify(tx.SenderPubKey, hash, tx.SenderSig) {
+		return ErrInvalidSignature
+	}
+	if !bytes.Equal(hashing.SHA3(tx.SenderPubKey), authKey) {
+		return ErrAuthKeyMismatch
+	}
+	return nil
+}
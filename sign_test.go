@@ -0,0 +1,227 @@
+package libra_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/go-test/deep"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/sha3"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestRawTransactionSign tests if libra.RawTransaction.Sign(...) signs the hash of the raw transaction.
+func TestRawTransactionSign(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx := newTestRawTx(libra.AccountAddressFromPublicKey(publicKey))
+	tx, err := rawTx.Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := rawTx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(publicKey, hash, tx.SenderSig) {
+		t.Fatal("Invalid signature")
+	}
+	if !bytes.Equal(tx.SenderPubKey, publicKey) {
+		t.Fatalf("Expected public key %x, but was %x", publicKey, tx.SenderPubKey)
+	}
+	decoded, err := libra.RawTransactionFromBytes(tx.RawBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(decoded, rawTx); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+// TestRawTransactionHashKnownAnswer tests libra.RawTransaction.Hash() and the signature of libra.RawTransaction.Sign(...)
+// against a hash that's computed independently of the SDK's serializer and hashing package,
+// from canonical serialization bytes that are written out by hand following Libra's canonical serialization spec.
+// The salt scheme itself is covered by the testnet access path in the accesspath tests.
+func TestRawTransactionHashKnownAnswer(t *testing.T) {
+	sender, err := libra.ParseAccountAddress("3a24a61e05d129cace9e0efc8bc9e33831fec9a9be66f50fd352a2638a49b9ee")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx := libra.RawTransaction{
+		Sender:     sender,
+		SequenceNo: 32,
+		Program: &libra.Program{
+			Code: []byte("move"),
+			Arguments: []libra.TransactionArgument{
+				{Type: types.TransactionArgument_STRING, Data: []byte("CAFE D00D")},
+				{Type: types.TransactionArgument_STRING, Data: []byte("cafe d00d")},
+			},
+			Modules: [][]byte{{0xca}, {0xfe, 0xd0}, {0x0d}},
+		},
+		MaxGasAmount:   10000,
+		GasUnitPrice:   20000,
+		ExpirationTime: 86400,
+	}
+	serialized, err := hex.DecodeString("" +
+		// Sender (length prefixed), sequence number
+		"20000000" + "3a24a61e05d129cace9e0efc8bc9e33831fec9a9be66f50fd352a2638a49b9ee" + "2000000000000000" +
+		// Payload variant Program, code
+		"00000000" + "04000000" + "6d6f7665" +
+		// Two STRING arguments
+		"02000000" + "02000000" + "09000000" + "434146452044303044" + "02000000" + "09000000" + "636166652064303064" +
+		// Three modules
+		"03000000" + "01000000" + "ca" + "02000000" + "fed0" + "01000000" + "0d" +
+		// Max gas amount, gas unit price, expiration time
+		"1027000000000000" + "204e000000000000" + "8051010000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt := sha3.Sum256([]byte("RawTransaction@@$$LIBRA$$@@"))
+	expected := sha3.Sum256(append(salt[:], serialized...))
+
+	hash, err := rawTx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(hash, expected[:]) {
+		t.Fatalf("Expected the hash %x, but was %x", expected, hash)
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	tx, err := rawTx.Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(privateKey.Public().(ed25519.PublicKey), expected[:], tx.SenderSig) {
+		t.Fatal("Expected the signature to be valid for the expected hash")
+	}
+}
+
+// TestRawTransactionHash tests if libra.RawTransaction.Hash() depends on all fields.
+func TestRawTransactionHash(t *testing.T) {
+	rawTx := newTestRawTx(libra.AccountAddress{1})
+	hash, err := rawTx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := rawTx
+	changed.ExpirationTime++
+	changedHash, err := changed.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(hash, changedHash) {
+		t.Fatal("Expected a different hash after changing the expiration time")
+	}
+
+	rawTx.Program.Arguments[1].Data = []byte{1}
+	if _, err := rawTx.Hash(); err == nil {
+		t.Fatal("Expected an error for an invalid U64 argument")
+	}
+}
+
+// TestAccountAddressFromPublicKey tests if the address is the SHA3-256 hash of the public key.
+func TestAccountAddressFromPublicKey(t *testing.T) {
+	publicKey := ed25519.PublicKey(bytes.Repeat([]byte{1}, ed25519.PublicKeySize))
+	expected := sha3.Sum256(publicKey)
+	if addr := libra.AccountAddressFromPublicKey(publicKey); addr != expected {
+		t.Fatalf("Expected %x, but was %v", expected, addr)
+	}
+}
+
+// newTestRawTx creates a raw transaction with a synthetic transfer script.
+func newTestRawTx(sender libra.AccountAddress) libra.RawTransaction {
+	return libra.RawTransaction{
+		Sender:     sender,
+		SequenceNo: 3,
+		Program: &libra.Program{
+			Code: []byte{1, 2, 3},
+			Arguments: []libra.TransactionArgument{
+				{Type: types.TransactionArgument_ADDRESS, Data: bytes.Repeat([]byte{2}, libra.AccountAddressLength)},
+				{Type: types.TransactionArgument_U64, Data: []byte{4, 0, 0, 0, 0, 0, 0, 0}},
+			},
+		},
+		MaxGasAmount:   140000,
+		GasUnitPrice:   1,
+		ExpirationTime: 1563000000,
+	}
+}
+
+// TestTransactionVerify tests if libra.Transaction.Verify() detects invalid signatures and foreign public keys.
+func TestTransactionVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := libra.AccountAddressFromPublicKey(publicKey)
+	tx, err := newTestRawTx(sender).Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	manipulated := tx
+	rawTx, _ := libra.RawTransactionFromBytes(tx.RawBytes)
+	rawTx.SequenceNo++
+	manipulated.RawBytes, err = rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manipulated.Verify(); err != libra.ErrInvalidSignature {
+		t.Fatalf("Expected %v, but was %v", libra.ErrInvalidSignature, err)
+	}
+
+	// Signed with the key of another account
+	otherTx, err := newTestRawTx(libra.AccountAddress{1}).Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := otherTx.Verify(); err != libra.ErrAuthKeyMismatch {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAuthKeyMismatch, err)
+	}
+	// Valid if the other account's key was rotated to the signer's key
+	if err := otherTx.VerifyAuthKey(sender.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClientVerifyTransaction tests if libra.Client.VerifyTransaction(...) checks the public key
+// against the authentication key of the sender on the ledger, which differs from the address after a key rotation.
+func TestClientVerifyTransaction(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, c, sender := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+	tx, err := newTestRawTx(sender).Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyTransaction(context.Background(), tx); err != libra.ErrAuthKeyMismatch {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAuthKeyMismatch, err)
+	}
+
+	// Rotate the sender's key to the signer's key
+	s.SetAccount(sender, libra.AccountResource{AuthKey: libra.AccountAddressFromPublicKey(publicKey).Bytes()})
+	if err := c.VerifyTransaction(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+
+	unknownTx, err := newTestRawTx(libra.AccountAddress{1}).Sign(libra.PrivateKeySigner(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyTransaction(context.Background(), unknownTx); err != libra.ErrAccountNotFound {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAccountNotFound, err)
+	}
+}
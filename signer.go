@@ -0,0 +1,163 @@
+package libra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// Signer signs transactions.
+// Implementations can keep the private key outside of the process, e.g. in an HSM, a KMS or a vault.
+type Signer interface {
+	// PublicKey returns the ed25519 public key that belongs to the signing key.
+	PublicKey() ed25519.PublicKey
+	// Sign signs the given hash of a raw transaction and returns the ed25519 signature.
+	Sign(hash []byte) ([]byte, error)
+}
+
+// PrivateKeySigner is a Signer with an in-memory ed25519 private key.
+type PrivateKeySigner ed25519.PrivateKey
+
+// PublicKey implements Signer.
+func (s PrivateKeySigner) PublicKey() ed25519.PublicKey {
+	return ed25519.PrivateKey(s).Public().(ed25519.PublicKey)
+}
+
+// Sign implements Signer.
+func (s PrivateKeySigner) Sign(hash []byte) ([]byte, error) {
+	if len(s) != ed25519.PrivateKeySize {
+		return nil, errors.New("Invalid private key length")
+	}
+	return ed25519.Sign(ed25519.PrivateKey(s), hash), nil
+}
+
+// AccountSigner is a Signer for the account with the given address.
+// It's needed after the account's authentication key was rotated,
+// because then the address can't be derived from the signer's public key anymore.
+type AccountSigner struct {
+	Signer
+	Address AccountAddress
+}
+
+// SenderOf returns the address of the account the signer signs transactions for.
+// It's AccountSigner.Address for an AccountSigner or *AccountSigner
+// and the address that's derived from the public key for other signers.
+func SenderOf(signer Signer) AccountAddress {
+	switch accSigner := signer.(type) {
+	case AccountSigner:
+		return accSigner.Address
+	case *AccountSigner:
+		return accSigner.Address
+	}
+	return AccountAddressFromPublicKey(signer.PublicKey())
+}
+
+// DefaultCommandTimeout is the timeout of a CommandSigner's command, unless CommandSigner.Timeout is set.
+const DefaultCommandTimeout = 30 * time.Second
+
+// CommandSigner is a Signer that runs an external command for each request.
+//
+// The command gets a JSON request as single line on stdin and must write a JSON response as single line to stdout:
+//
+//	{"method":"public_key"}                 ->  {"public_key":"0x..."}
+//	{"method":"sign","hash":"0x..."}        ->  {"signature":"0x..."}
+//
+// Byte slices are "0x"-prefixed hex strings. In case of an error, the command can respond with {"error":"..."}
+// or exit with a non-zero exit code. Its stderr is included in the returned error.
+type CommandSigner struct {
+	// Name and Args of the command, see exec.Command(...)
+	Name string
+	Args []string
+	// Timeout of each command execution. If 0, DefaultCommandTimeout is used.
+	Timeout time.Duration
+
+	publicKey ed25519.PublicKey
+}
+
+// commandRequest is the request that a CommandSigner writes to the command's stdin.
+type commandRequest struct {
+	Method string   `json:"method"`
+	Hash   hexBytes `json:"hash,omitempty"`
+}
+
+// commandResponse is the response that a CommandSigner reads from the command's stdout.
+type commandResponse struct {
+	PublicKey hexBytes `json:"public_key"`
+	Signature hexBytes `json:"signature"`
+	Error     string   `json:"error"`
+}
+
+// NewCommandSigner creates a CommandSigner and requests the public key from the command.
+func NewCommandSigner(name string, args ...string) (*CommandSigner, error) {
+	s := &CommandSigner{
+		Name: name,
+		Args: args,
+	}
+	res, err := s.run(commandRequest{Method: "public_key"})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("The signer command returned a public key with an invalid length: %v bytes", len(res.PublicKey))
+	}
+	s.publicKey = ed25519.PublicKey(res.PublicKey)
+	return s, nil
+}
+
+// PublicKey implements Signer.
+func (s *CommandSigner) PublicKey() ed25519.PublicKey {
+	return s.publicKey
+}
+
+// Sign implements Signer.
+// The signature that's returned by the command is verified with the public key.
+func (s *CommandSigner) Sign(hash []byte) ([]byte, error) {
+	res, err := s.run(commandRequest{
+		Method: "sign",
+		Hash:   hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(s.publicKey, hash, res.Signature) {
+		return nil, errors.New("The signer command returned an invalid signature")
+	}
+	return res.Signature, nil
+}
+
+// run runs the command with the given request.
+func (s *CommandSigner) run(req commandRequest) (commandResponse, error) {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	reqJSON, err := json.Marshal(req)
+	if err != nil {
+		return commandResponse{}, err
+	}
+	cmd := exec.CommandContext(ctx, s.Name, s.Args...)
+	cmd.Stdin = bytes.NewReader(append(reqJSON, '\n'))
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.Output()
+	if err != nil {
+		return commandResponse{}, fmt.Errorf("The signer command failed: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	var res commandResponse
+	if err := json.Unmarshal(bytes.TrimSpace(stdout), &res); err != nil {
+		return commandResponse{}, fmt.Errorf("The signer command returned an invalid response: %v", err)
+	}
+	if res.Error != "" {
+		return commandResponse{}, fmt.Errorf("The signer command returned an error: %v", res.Error)
+	}
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package libra_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// testSignerKey is the private key of the test signer command
+var testSignerKey = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+
+// TestCommandSigner tests if libra.CommandSigner gets the public key and signatures from an external command.
+// The command is this test binary, running TestHelperSignerCommand.
+func TestCommandSigner(t *testing.T) {
+	signer, err := libra.NewCommandSigner(os.Args[0], "-test.run=TestHelperSignerCommand", "--", "sign")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(signer.PublicKey(), testSignerKey.Public().(ed25519.PublicKey)) {
+		t.Fatalf("Expected public key %x, but was %x", testSignerKey.Public(), signer.PublicKey())
+	}
+
+	rawTx := newTestRawTx(libra.AccountAddressFromPublicKey(signer.PublicKey()))
+	tx, err := rawTx.Sign(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := rawTx.Sign(libra.PrivateKeySigner(testSignerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tx.SenderSig, expected.SenderSig) {
+		t.Fatalf("Expected signature %x, but was %x", expected.SenderSig, tx.SenderSig)
+	}
+}
+
+// TestCommandSignerError tests if errors of the external command are returned.
+func TestCommandSignerError(t *testing.T) {
+	_, err := libra.NewCommandSigner(os.Args[0], "-test.run=TestHelperSignerCommand", "--", "fail")
+	if err == nil || !strings.Contains(err.Error(), "key is locked") {
+		t.Fatalf("Expected the error of the command, but was %v", err)
+	}
+}
+
+// TestHelperSignerCommand isn't a real test. It's the external command for the CommandSigner tests.
+func TestHelperSignerCommand(t *testing.T) {
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	if len(args) != 2 {
+		return
+	}
+	defer os.Exit(0)
+
+	if args[1] == "fail" {
+		fmt.Println(`{"error":"key is locked"}`)
+		return
+	}
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	var req struct {
+		Method string `json:"method"`
+		Hash   string `json:"hash"`
+	}
+	if err := json.Unmarshal([]byte(line), &req); err != nil {
+		fmt.Printf(`{"error":%q}`+"\n", err.Error())
+		return
+	}
+	switch req.Method {
+	case "public_key":
+		fmt.Printf(`{"public_key":"0x%x"}`+"\n", testSignerKey.Public())
+	case "sign":
+		hash, _ := hex.DecodeString(strings.TrimPrefix(req.Hash, "0x"))
+		fmt.Printf(`{"signature":"0x%x"}`+"\n", ed25519.Sign(testSignerKey, hash))
+	}
+}
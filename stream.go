@@ -0,0 +1,136 @@
+package libra
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// DefaultStreamBatchSize is the number of transactions a LedgerStream requests at once, unless LedgerStream.BatchSize is set.
+const DefaultStreamBatchSize = 100
+
+// ErrLedgerGap is returned by a LedgerStream when a node returns transactions that don't start with the requested version.
+var ErrLedgerGap = errors.New("The returned transactions don't start with the requested version")
+
+// LedgerStream streams all committed transactions of the ledger with their transaction infos and events,
+// starting with a given version.
+//
+// Each batch of transactions is verified against the proofs of the response:
+// The hashes of the transactions and events must match the transaction infos,
+// and the transaction infos must be part of the transaction accumulator of the response's ledger info.
+// The signatures of the ledger info aren't verified.
+type LedgerStream struct {
+	// BatchSize is the maximum number of transactions that are requested at once.
+	// If 0, DefaultStreamBatchSize is used.
+	BatchSize uint64
+	// SkipVerification disables the verification of the transactions and events against the proofs.
+	SkipVerification bool
+	// Checkpoint is called after all transactions of a batch were handled, with the version of the next transaction.
+	// Persist it to resume the stream after a restart with Client.NewLedgerStream(...).
+	// If it returns an error, Run(...) stops.
+	Checkpoint func(next uint64) error
+
+	c Client
+	// next is the version of the next transaction. It's accessed atomically.
+	next uint64
+}
+
+// NewLedgerStream creates a stream of committed transactions, starting with the given version.
+// Call Run(...) to start it.
+func (c Client) NewLedgerStream(start uint64) *LedgerStream {
+	return &LedgerStream{
+		c:    c,
+		next: start,
+	}
+}
+
+// Next returns the version of the next transaction that will be passed to the handler.
+// It's safe to call it while the stream is running.
+func (ls *LedgerStream) Next() uint64 {
+	return atomic.LoadUint64(&ls.next)
+}
+
+// Run passes each committed transaction to the handler, in the order of their versions.
+// A batch is only requested after all transactions of the previous batch were handled,
+// so a slow handler slows down the stream instead of transactions piling up in memory.
+// When the end of the ledger is reached, Run polls for new transactions with a growing interval.
+//
+// Nodes that are behind the stream's version, e.g. after a multi-node client switched to another node,
+// are treated like the end of the ledger. Transactions that don't start with the requested version
+// lead to ErrLedgerGap, invalid proofs lead to a ProofError.
+//
+// Run returns when the context is done, when the handler or Checkpoint returns an error,
+// or when a request fails. It can be called again to continue with the next transaction.
+func (ls *LedgerStream) Run(ctx context.Context, handle func(CommittedTransaction) error) error {
+	batchSize := ls.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultStreamBatchSize
+	}
+	interval := minPollInterval
+	for {
+		txs, err := ls.fetch(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			if err := handle(tx); err != nil {
+				return err
+			}
+			atomic.StoreUint64(&ls.next, tx.Version+1)
+		}
+		if len(txs) > 0 {
+			if ls.Checkpoint != nil {
+				if err := ls.Checkpoint(ls.Next()); err != nil {
+					return err
+				}
+			}
+			interval = minPollInterval
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = interval * 3 / 2
+		if interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
+
+// fetch requests and verifies the next batch of transactions.
+func (ls *LedgerStream) fetch(ctx context.Context, limit uint64) ([]CommittedTransaction, error) {
+	next := ls.Next()
+	requestItem := &types.RequestItem{
+		RequestedItems: &types.RequestItem_GetTransactionsRequest{
+			GetTransactionsRequest: &types.GetTransactionsRequest{
+				StartVersion: next,
+				Limit:        limit,
+				FetchEvents:  true,
+			},
+		},
+	}
+	responseItem, ledgerInfo, err := ls.c.requestItem(ctx, requestItem)
+	if err != nil {
+		return nil, err
+	}
+	txList := responseItem.GetGetTransactionsResponse().GetTxnListWithProof()
+	txs, err := transactionsFromProto(txList, true)
+	if err != nil || len(txs) == 0 {
+		return nil, err
+	}
+	if txs[0].Version != next {
+		return nil, ErrLedgerGap
+	}
+	if !ls.SkipVerification {
+		if err := verifyTransactionList(ledgerInfo, txs, txList, true); err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
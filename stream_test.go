@@ -0,0 +1,79 @@
+package libra_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// TestLedgerStream tests if a LedgerStream passes all committed transactions with their events to the handler,
+// including transactions that are committed while it's running, and if it can be resumed from a checkpoint.
+func TestLedgerStream(t *testing.T) {
+	s, c, sender := newTestServerAndClient(t, libra.AccountResource{Balance: 100 * libra.Libra})
+	defer s.Close()
+	defer c.Close()
+	receiver := libra.AccountAddress{2}
+	for seqNo := uint64(0); seqNo < 3; seqNo++ {
+		if err := c.SendTx(newTestTransfer(t, sender, seqNo, receiver, libra.Libra)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stream := c.NewLedgerStream(1)
+	stream.BatchSize = 2
+	var checkpoints []uint64
+	stream.Checkpoint = func(next uint64) error {
+		checkpoints = append(checkpoints, next)
+		return nil
+	}
+	errDone := errors.New("done")
+	var versions []uint64
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := stream.Run(ctx, func(tx libra.CommittedTransaction) error {
+		versions = append(versions, tx.Version)
+		if len(tx.Events) != 2 {
+			t.Fatalf("Expected 2 events, but got %v", len(tx.Events))
+		}
+		if tx.Version == 2 {
+			// Committed while the stream is running
+			if err := c.SendTx(newTestTransfer(t, sender, 3, receiver, libra.Libra)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tx.Version == 3 {
+			return errDone
+		}
+		return nil
+	})
+	if err != errDone {
+		t.Fatalf("Expected the error of the handler, but was %v", err)
+	}
+	if len(versions) != 3 || versions[0] != 1 || versions[2] != 3 {
+		t.Fatalf("Expected versions 1 to 3, but was %v", versions)
+	}
+	if len(checkpoints) != 1 || checkpoints[0] != 3 {
+		t.Fatalf("Expected one checkpoint with version 3, but was %v", checkpoints)
+	}
+	// The failed transaction wasn't handled, so it's passed again
+	if stream.Next() != 3 {
+		t.Fatalf("Expected next version 3, but was %v", stream.Next())
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	versions = nil
+	err = c.NewLedgerStream(3).Run(ctx, func(tx libra.CommittedTransaction) error {
+		versions = append(versions, tx.Version)
+		return nil
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected context.DeadlineExceeded, but was %v", err)
+	}
+	if len(versions) != 1 || versions[0] != 3 {
+		t.Fatalf("Expected only version 3, but was %v", versions)
+	}
+}
@@ -0,0 +1,104 @@
+package libra
+
+import (
+	"bytes"
+	"context"
+	"time"
+)
+
+// DefaultTransactionTTL is how long the transactions that are created by the Client's transaction helpers are valid,
+// e.g. by RotateAuthenticationKey(...), relative to the ledger's timestamp. It's the same as in the Libra CLI.
+const DefaultTransactionTTL = 100 * time.Second
+
+// WithTransactionTTL returns a copy of the client whose transaction helpers, like Transfer(...),
+// create transactions that expire the given duration after the ledger's timestamp instead of DefaultTransactionTTL.
+func (c Client) WithTransactionTTL(ttl time.Duration) Client {
+	c.txTTL = ttl
+	return c
+}
+
+// buildFunc creates a raw transaction of the sender with the given sequence number and fee.
+type buildFunc func(sender AccountAddress, seqNo uint64, fee Fee) (RawTransaction, error)
+
+// submitScript creates a transaction with the given builder, signs it and sends it.
+// The transaction gets the sender's current sequence number from the ledger, or from the Client's SequenceManager
+// if one is set (see Client.WithSequenceManager(...)), the fee that's set via Client.WithFee(...) or one that's estimated
+// from recent transactions with the given script code, and an expiration time DefaultTransactionTTL
+// (or the one set via Client.WithTransactionTTL(...)) after the ledger's timestamp.
+// Before sending, it checks if the fee is within the bounds of the Libra VM, if the signer's public key matches
+// the sender's authentication key and if the sender's balance covers the max fee plus the given amount.
+// The sequence number of the sent transaction is returned.
+func (c Client) submitScript(ctx context.Context, signer Signer, code []byte, amount Amount, build buildFunc) (uint64, error) {
+	sender := SenderOf(signer)
+	accState, err := c.GetAccountStateContext(ctx, sender.String())
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(AccountAddressFromPublicKey(signer.PublicKey()).Bytes(), accState.AccountResource.AuthKey) {
+		return 0, ErrAuthKeyMismatch
+	}
+	fee, err := c.transactionFee(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+	if err := fee.Validate(); err != nil {
+		return 0, err
+	}
+	if err := c.CheckBalance(ctx, sender, fee, amount); err != nil {
+		return 0, err
+	}
+
+	if c.seqNos == nil {
+		seqNo := accState.AccountResource.SequenceNo
+		tx, err := c.signScript(ctx, signer, sender, seqNo, fee, build)
+		if err != nil {
+			return 0, err
+		}
+		if err := c.SendTxContext(ctx, tx); err != nil {
+			return 0, err
+		}
+		return seqNo, nil
+	}
+
+	seqNo, err := c.seqNos.Reserve(ctx, sender)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := c.signScript(ctx, signer, sender, seqNo, fee, build)
+	if err != nil {
+		c.seqNos.Release(sender, seqNo)
+		return 0, err
+	}
+	err = c.SendTxContext(ctx, tx)
+	if doneErr := c.seqNos.Done(ctx, sender, seqNo, err); err == nil {
+		err = doneErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return seqNo, nil
+}
+
+// signScript creates a transaction with the given builder, checks its arguments,
+// sets its expiration time the client's transaction TTL after the ledger's timestamp and signs it.
+func (c Client) signScript(ctx context.Context, signer Signer, sender AccountAddress, seqNo uint64, fee Fee, build buildFunc) (Transaction, error) {
+	rawTx, err := build(sender, seqNo, fee)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if rawTx.Program != nil {
+		if err := rawTx.Program.CheckArguments(); err != nil {
+			return Transaction{}, err
+		}
+	}
+	ttl := c.txTTL
+	if ttl == 0 {
+		ttl = DefaultTransactionTTL
+	}
+	expiration, err := c.Expiration(ctx, ttl)
+	if err != nil {
+		return Transaction{}, err
+	}
+	rawTx.SetExpiration(expiration)
+	return rawTx.Sign(signer)
+}
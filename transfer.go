@@ -0,0 +1,25 @@
+package libra
+
+import (
+	"context"
+)
+
+// Transfer sends a transaction with the peer-to-peer transfer script,
+// which transfers the given amount from the signer's account to the receiver.
+// The script must be registered, see RegisterScript(...).
+//
+// The transaction gets the sender's current sequence number, or one from the client's SequenceManager
+// (see Client.WithSequenceManager(...)), and an estimated fee, unless it's set via Client.WithFee(...).
+// Before sending it, the signer's public key is checked against the sender's authentication key (ErrAuthKeyMismatch)
+// and the balance of the sender against the max fee plus the amount (InsufficientBalanceError).
+// For accounts with a rotated key, use an AccountSigner.
+// The sequence number of the sent transaction is returned, which can be used for WaitForTransaction(...).
+func (c Client) Transfer(ctx context.Context, signer Signer, receiver AccountAddress, amount Amount) (uint64, error) {
+	script, err := GetScript(ScriptPeerToPeerTransfer)
+	if err != nil {
+		return 0, err
+	}
+	return c.submitScript(ctx, signer, script.Code, amount, func(sender AccountAddress, seqNo uint64, fee Fee) (RawTransaction, error) {
+		return NewTransferTransaction(sender, seqNo, receiver, amount, fee)
+	})
+}
@@ -0,0 +1,80 @@
+package libra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+)
+
+// TestTransfer tests if libra.Client.Transfer(...) transfers the amount with the transaction TTL of the client,
+// and checks the signer's key and the sender's balance before sending.
+func TestTransfer(t *testing.T) {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptPeerToPeerTransfer,
+		Code: []byte{1, 2, 3},
+	})
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := libra.PrivateKeySigner(privateKey)
+	sender := libra.SenderOf(signer)
+
+	s, err := libratest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ledgerTime := time.Unix(1563000000, 0)
+	s.Clock = func() time.Time {
+		return ledgerTime
+	}
+	s.VerifySignatures = true
+	s.SetAccount(sender, libra.AccountResource{Balance: 10 * libra.Libra, SequenceNo: 2, AuthKey: sender.Bytes()})
+	c, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	receiver := libra.AccountAddress{5}
+	seqNo, err := c.WithTransactionTTL(time.Minute).Transfer(context.Background(), signer, receiver, 4*libra.Libra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seqNo != 2 {
+		t.Fatalf("Expected sequence number 2, but was %v", seqNo)
+	}
+	if accRes, _ := s.Account(receiver); accRes.Balance != 4*libra.Libra {
+		t.Fatalf("Expected a balance of 4 LBR, but was %v LBR", accRes.Balance)
+	}
+	tx, err := c.GetAccountTransaction(context.Background(), sender, seqNo, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTx, err := libra.RawTransactionFromBytes(tx.Transaction.RawBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := ledgerTime.Add(time.Minute); !rawTx.Expiration().Equal(expected) {
+		t.Fatalf("Expected the expiration time %v, but was %v", expected, rawTx.Expiration())
+	}
+
+	_, err = c.Transfer(context.Background(), signer, receiver, 7*libra.Libra)
+	if _, ok := err.(libra.InsufficientBalanceError); !ok {
+		t.Fatalf("Expected an InsufficientBalanceError, but was %v", err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSigner := libra.AccountSigner{Signer: libra.PrivateKeySigner(otherKey), Address: sender}
+	if _, err = c.Transfer(context.Background(), otherSigner, receiver, libra.Libra); err != libra.ErrAuthKeyMismatch {
+		t.Fatalf("Expected %v, but was %v", libra.ErrAuthKeyMismatch, err)
+	}
+}
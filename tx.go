@@ -1,8 +1,92 @@
 package libra
 
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
 // Transaction is a transaction of Libra Coins.
 type Transaction struct {
 	RawBytes     []byte
 	SenderPubKey []byte
 	SenderSig    []byte
 }
+
+// transactionJSON is the JSON representation of a Transaction.
+// The field names are the ones of the SignedTransaction in Libra's gRPC API.
+type transactionJSON struct {
+	RawBytes     hexBytes `json:"raw_txn_bytes"`
+	SenderPubKey hexBytes `json:"sender_public_key"`
+	SenderSig    hexBytes `json:"sender_signature"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// All fields are encoded as "0x"-prefixed hex string.
+func (tx Transaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transactionJSON{
+		RawBytes:     tx.RawBytes,
+		SenderPubKey: tx.SenderPubKey,
+		SenderSig:    tx.SenderSig,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (tx *Transaction) UnmarshalJSON(data []byte) error {
+	var v transactionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*tx = Transaction{
+		RawBytes:     v.RawBytes,
+		SenderPubKey: v.SenderPubKey,
+		SenderSig:    v.SenderSig,
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text form is the "0x"-prefixed hex encoded protobuf SignedTransaction,
+// which is what gets sent to a validator node.
+func (tx Transaction) MarshalText() ([]byte, error) {
+	b, err := proto.Marshal(tx.toProto())
+	if err != nil {
+		return nil, err
+	}
+	return hexBytes(b).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It decodes a (optionally "0x"-prefixed) hex encoded protobuf SignedTransaction.
+func (tx *Transaction) UnmarshalText(text []byte) error {
+	var b hexBytes
+	if err := b.UnmarshalText(text); err != nil {
+		return err
+	}
+	signedTx := types.SignedTransaction{}
+	if err := proto.Unmarshal(b, &signedTx); err != nil {
+		return err
+	}
+	*tx = transactionFromProto(&signedTx)
+	return nil
+}
+
+// toProto converts the transaction into the SignedTransaction of Libra's gRPC API.
+func (tx Transaction) toProto() *types.SignedTransaction {
+	return &types.SignedTransaction{
+		RawTxnBytes:     tx.RawBytes,
+		SenderPublicKey: tx.SenderPubKey,
+		SenderSignature: tx.SenderSig,
+	}
+}
+
+// transactionFromProto converts a SignedTransaction of Libra's gRPC API into a Transaction.
+func transactionFromProto(signedTx *types.SignedTransaction) Transaction {
+	return Transaction{
+		RawBytes:     signedTx.GetRawTxnBytes(),
+		SenderPubKey: signedTx.GetSenderPublicKey(),
+		SenderSig:    signedTx.GetSenderSignature(),
+	}
+}
@@ -0,0 +1,49 @@
+package libra_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// TestTransactionJSON tests if libra.Transaction can be encoded to JSON and text and decoded again.
+func TestTransactionJSON(t *testing.T) {
+	tx := libra.Transaction{
+		RawBytes:     []byte{1, 2, 3},
+		SenderPubKey: []byte{4, 5, 6},
+		SenderSig:    []byte{7, 8, 9},
+	}
+
+	b, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"raw_txn_bytes":"0x010203","sender_public_key":"0x040506","sender_signature":"0x070809"}`
+	if string(b) != expected {
+		t.Fatalf("Expected %v, but was %v", expected, string(b))
+	}
+	var decoded libra.Transaction
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(decoded, tx); diff != nil {
+		t.Fatal(diff)
+	}
+
+	text, err := tx.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = libra.Transaction{}
+	err = decoded.UnmarshalText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(decoded, tx); diff != nil {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,154 @@
+package libra
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+const (
+	// Polling interval of WaitForTransaction(...), starting with the min value and growing up to the max value
+	minPollInterval = 100 * time.Millisecond
+	maxPollInterval = 2 * time.Second
+	// sentTxRetention is how long the expiration time of a sent transaction is kept after it passed
+	sentTxRetention = 10 * time.Minute
+)
+
+// ErrTransactionExpired is returned when a transaction wasn't committed before its expiration time,
+// and by Client.CheckExpiration(...) for transactions that would be rejected because they expired.
+var ErrTransactionExpired = errors.New("The transaction expired without being committed")
+
+// TransactionResult is the result of a committed transaction.
+type TransactionResult struct {
+	// Version of the ledger at which the transaction was committed
+	Version uint64
+	// GasUsed is the amount of gas units the transaction used
+	GasUsed uint64
+	// Events that were emitted by the transaction, e.g. for sent and received payments
+	Events []*types.Event
+}
+
+// txKey identifies a transaction by its sender and sequence number.
+type txKey struct {
+	sender AccountAddress
+	seqNo  uint64
+}
+
+// sentTxs keeps the expiration times of sent transactions,
+// so that WaitForTransaction(...) can stop waiting when a transaction expired.
+type sentTxs struct {
+	lock        sync.Mutex
+	expirations map[txKey]time.Time
+}
+
+func newSentTxs() *sentTxs {
+	return &sentTxs{
+		expirations: make(map[txKey]time.Time),
+	}
+}
+
+// add records the expiration time of a sent transaction.
+// Transactions without expiration time or with an invalid raw transaction are ignored.
+func (st *sentTxs) add(tx Transaction) {
+	rawTx := types.RawTransaction{}
+	if err := proto.Unmarshal(tx.RawBytes, &rawTx); err != nil || rawTx.GetExpirationTime() == 0 {
+		return
+	}
+	sender, err := AccountAddressFromBytes(rawTx.GetSenderAccount())
+	if err != nil {
+		return
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	// Remove old entries of transactions nobody waited for
+	now := time.Now()
+	for key, expiration := range st.expirations {
+		if now.Sub(expiration) > sentTxRetention {
+			delete(st.expirations, key)
+		}
+	}
+	st.expirations[txKey{sender: sender, seqNo: rawTx.GetSequenceNumber()}] = time.Unix(int64(rawTx.GetExpirationTime()), 0)
+}
+
+// get returns the expiration time of a sent transaction.
+// false is returned if the transaction wasn't sent by this client or has no expiration time.
+func (st *sentTxs) get(key txKey) (time.Time, bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	expiration, ok := st.expirations[key]
+	return expiration, ok
+}
+
+func (st *sentTxs) remove(key txKey) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	delete(st.expirations, key)
+}
+
+// WaitForTransaction waits until the transaction of the given sender with the given sequence number is committed.
+// It polls the validator node with a growing interval.
+//
+// If the transaction was sent with this client, ErrTransactionExpired is returned as soon as the
+// ledger's timestamp passes the transaction's expiration time without the transaction being committed.
+// Otherwise it waits until the context is done. For transactions that were sent elsewhere,
+// use WaitForTransactionUntil(...) with the transaction's expiration time.
+func (c Client) WaitForTransaction(ctx context.Context, sender AccountAddress, seqNo uint64) (TransactionResult, error) {
+	key := txKey{sender: sender, seqNo: seqNo}
+	expiration, _ := c.sent.get(key)
+	res, err := c.WaitForTransactionUntil(ctx, sender, seqNo, expiration)
+	// Keep the expiration time for waiting again after the context was done or a request failed
+	if err == nil || err == ErrTransactionExpired {
+		c.sent.remove(key)
+	}
+	return res, err
+}
+
+// WaitForTransactionUntil is like WaitForTransaction(...), but ErrTransactionExpired is returned as soon as the
+// ledger's timestamp passes the given expiration time, e.g. RawTransaction.Expiration() of the transaction.
+// A zero expiration time means that it waits until the context is done.
+func (c Client) WaitForTransactionUntil(ctx context.Context, sender AccountAddress, seqNo uint64, expiration time.Time) (TransactionResult, error) {
+	interval := minPollInterval
+	for {
+		requestItem := &types.RequestItem{
+			RequestedItems: &types.RequestItem_GetAccountTransactionBySequenceNumberRequest{
+				GetAccountTransactionBySequenceNumberRequest: &types.GetAccountTransactionBySequenceNumberRequest{
+					Account:        sender.Bytes(),
+					SequenceNumber: seqNo,
+					FetchEvents:    true,
+				},
+			},
+		}
+		responseItem, ledgerInfo, err := c.requestItem(ctx, requestItem)
+		if err != nil {
+			return TransactionResult{}, err
+		}
+		txWithProof := responseItem.GetGetAccountTransactionBySequenceNumberResponse().GetSignedTransactionWithProof()
+		if txWithProof.GetSignedTransaction() != nil {
+			return TransactionResult{
+				Version: txWithProof.GetVersion(),
+				GasUsed: txWithProof.GetProof().GetTransactionInfo().GetGasUsed(),
+				Events:  txWithProof.GetEvents().GetEvents(),
+			}, nil
+		}
+		// The expiration time is compared with the ledger's timestamp, not the local clock.
+		if !expiration.IsZero() && !TimeFromLedgerTimestamp(ledgerInfo.GetTimestampUsecs()).Before(expiration) {
+			return TransactionResult{}, ErrTransactionExpired
+		}
+
+		select {
+		case <-ctx.Done():
+			return TransactionResult{}, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = interval * 3 / 2
+		if interval > maxPollInterval {
+			interval = maxPollInterval
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package libra_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/libratest"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestWaitForTransaction tests if libra.Client.WaitForTransaction(...) returns the result of a committed transaction.
+func TestWaitForTransaction(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+
+	err := c.SendTx(newTestTx(t, addr, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := c.WaitForTransaction(ctx, addr, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != 0 {
+		t.Fatalf("Expected version 0, but was %v", res.Version)
+	}
+	if res.GasUsed != libratest.DefaultGasUsed {
+		t.Fatalf("Expected %v gas used, but was %v", libratest.DefaultGasUsed, res.GasUsed)
+	}
+}
+
+// testClock is a ledger clock for libratest.Server.Clock that only moves when it's advanced.
+type testClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Unix(1563000000, 0)}
+}
+
+func (tc *testClock) Now() time.Time {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	return tc.now
+}
+
+func (tc *testClock) Advance(d time.Duration) {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	tc.now = tc.now.Add(d)
+}
+
+// TestWaitForTransactionExpired tests if libra.Client.WaitForTransaction(...) stops waiting
+// when a transaction that wasn't committed expires.
+func TestWaitForTransactionExpired(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+	clock := newTestClock()
+	s.Clock = clock.Now
+
+	// The sequence number 1 leads to a gap, so the transaction doesn't get executed
+	rawTxBytes, err := proto.Marshal(&types.RawTransaction{
+		SenderAccount:  addr.Bytes(),
+		SequenceNumber: 1,
+		ExpirationTime: uint64(clock.Now().Add(time.Second).Unix()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SendTx(libra.Transaction{RawBytes: rawTxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = c.WaitForTransaction(ctx, addr, 1)
+	if err != libra.ErrTransactionExpired {
+		t.Fatalf("Expected %v, but was %v", libra.ErrTransactionExpired, err)
+	}
+}
+
+// TestWaitForTransactionUntil tests if libra.Client.WaitForTransactionUntil(...) stops waiting at the given expiration time
+// for transactions that weren't sent with the client, and if libra.Client.WaitForTransaction(...) keeps the expiration time
+// of sent transactions when the context is done.
+func TestWaitForTransactionUntil(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{})
+	defer s.Close()
+	defer c.Close()
+	other, err := libra.NewClient(s.Addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	clock := newTestClock()
+	s.Clock = clock.Now
+
+	// The sequence number 1 leads to a gap, so the transaction doesn't get executed
+	expiration := clock.Now().Add(time.Second)
+	rawTxBytes, err := proto.Marshal(&types.RawTransaction{
+		SenderAccount:  addr.Bytes(),
+		SequenceNumber: 1,
+		ExpirationTime: uint64(expiration.Unix()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.SendTx(libra.Transaction{RawBytes: rawTxBytes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = other.WaitForTransactionUntil(ctx, addr, 1, expiration)
+	if err != libra.ErrTransactionExpired {
+		t.Fatalf("Expected %v, but was %v", libra.ErrTransactionExpired, err)
+	}
+
+	// Waiting again after the context was done still returns ErrTransactionExpired
+	canceledCtx, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if _, err = c.WaitForTransaction(canceledCtx, addr, 1); err == nil || err == libra.ErrTransactionExpired {
+		t.Fatalf("Expected a context error, but was %v", err)
+	}
+	_, err = c.WaitForTransaction(ctx, addr, 1)
+	if err != libra.ErrTransactionExpired {
+		t.Fatalf("Expected %v, but was %v", libra.ErrTransactionExpired, err)
+	}
+}
@@ -0,0 +1,234 @@
+// Package wallet implements a hierarchical deterministic wallet that's compatible with the Libra CLI's wallet.
+//
+// All accounts of a wallet are derived from its mnemonic, so the mnemonic is all that's needed to recover them.
+// The Libra CLI writes the mnemonic and the number of generated accounts into a recovery file,
+// which can be read with Recover(...).
+package wallet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/sha3"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// Constants of the key derivation, the same as in the Libra CLI's wallet
+const (
+	mnemonicSaltPrefix = "LIBRA WALLET: mnemonic salt prefix$"
+	mnemonicSalt       = "LIBRA"
+	masterKeySalt      = "LIBRA WALLET: master key salt$"
+	derivedKeyInfo     = "LIBRA WALLET: derived key$"
+	pbkdf2Iterations   = 2048
+	// entropyBits leads to 24 word mnemonics
+	entropyBits = 256
+)
+
+// Account is an account of a wallet.
+// It implements libra.Signer.
+type Account struct {
+	// Index of the account within the wallet
+	Index uint64
+	// Address of the account, derived from the public key
+	Address    libra.AccountAddress
+	PrivateKey ed25519.PrivateKey
+}
+
+// PublicKey returns the public key of the account.
+func (a Account) PublicKey() ed25519.PublicKey {
+	return a.PrivateKey.Public().(ed25519.PublicKey)
+}
+
+// Sign implements libra.Signer.
+func (a Account) Sign(hash []byte) ([]byte, error) {
+	return libra.PrivateKeySigner(a.PrivateKey).Sign(hash)
+}
+
+// Wallet derives accounts from a mnemonic.
+type Wallet struct {
+	mnemonic  string
+	masterKey []byte
+
+	lock sync.Mutex
+	// Number of accounts that were created with NewAccount()
+	count uint64
+	// Indexes of the accounts whose keys are the current signing keys of accounts with a rotated authentication key
+	signingKeys map[libra.AccountAddress]uint64
+}
+
+// ErrUnknownAccount is returned when the wallet doesn't have a signing key for an account address.
+var ErrUnknownAccount = errors.New("The wallet doesn't have a key for the account")
+
+// New creates a wallet with a new random mnemonic.
+func New() (*Wallet, error) {
+	entropy, err := bip39.NewEntropy(entropyBits)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, err
+	}
+	return FromMnemonic(mnemonic)
+}
+
+// FromMnemonic creates a wallet from an existing mnemonic, e.g. one that was generated by the Libra CLI.
+// The mnemonic's words must be from the BIP39 English word list.
+func FromMnemonic(mnemonic string) (*Wallet, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if _, err := bip39.EntropyFromMnemonic(mnemonic); err != nil {
+		return nil, fmt.Errorf("Invalid mnemonic: %v", err)
+	}
+	seed := pbkdf2.Key([]byte(mnemonic), []byte(mnemonicSaltPrefix+mnemonicSalt), pbkdf2Iterations, 32, sha3.New256)
+	return &Wallet{
+		mnemonic:  mnemonic,
+		masterKey: hkdf.Extract(sha3.New256, seed, []byte(masterKeySalt)),
+	}, nil
+}
+
+// Recover creates a wallet from a recovery file of the Libra CLI.
+// The file contains the mnemonic and the number of generated accounts, separated by ";".
+// The accounts are generated again, so Accounts() returns them.
+func Recover(path string) (*Wallet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.TrimSpace(string(data)), ";")
+	if len(parts) != 2 {
+		return nil, errors.New("Invalid recovery file: Expected mnemonic and account count separated by \";\"")
+	}
+	count, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid recovery file: Invalid account count: %v", err)
+	}
+	w, err := FromMnemonic(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	w.count = count
+	return w, nil
+}
+
+// WriteRecovery writes a recovery file in the format of the Libra CLI.
+// The file contains the mnemonic, so it must be kept secret.
+func (w *Wallet) WriteRecovery(path string) error {
+	w.lock.Lock()
+	count := w.count
+	w.lock.Unlock()
+	return ioutil.WriteFile(path, []byte(w.mnemonic+";"+strconv.FormatUint(count, 10)), 0600)
+}
+
+// Mnemonic returns the wallet's mnemonic.
+// It must be kept secret, because all accounts can be derived from it.
+func (w *Wallet) Mnemonic() string {
+	return w.mnemonic
+}
+
+// Account derives the account with the given index.
+// It doesn't change the number of accounts the wallet keeps track of.
+func (w *Wallet) Account(index uint64) (Account, error) {
+	info := make([]byte, len(derivedKeyInfo)+8)
+	copy(info, derivedKeyInfo)
+	binary.LittleEndian.PutUint64(info[len(derivedKeyInfo):], index)
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := io.ReadFull(hkdf.Expand(sha3.New256, w.masterKey, info), seed); err != nil {
+		return Account{}, err
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return Account{
+		Index:      index,
+		Address:    libra.AccountAddressFromPublicKey(privateKey.Public().(ed25519.PublicKey)),
+		PrivateKey: privateKey,
+	}, nil
+}
+
+// NewAccount derives the next account of the wallet.
+func (w *Wallet) NewAccount() (Account, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	acc, err := w.Account(w.count)
+	if err != nil {
+		return Account{}, err
+	}
+	w.count++
+	return acc, nil
+}
+
+// Accounts returns all accounts that were created with NewAccount() or restored with Recover(...).
+func (w *Wallet) Accounts() ([]Account, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var result []Account
+	for i := uint64(0); i < w.count; i++ {
+		acc, err := w.Account(i)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, acc)
+	}
+	return result, nil
+}
+
+// SetSigningKey sets the key of the account with the given index as current signing key of the account with the given address,
+// e.g. after rotating the authentication key of the account with libra.Client.RotateAuthenticationKey(...).
+// Signer(...) returns a signer with that key for the address afterwards.
+//
+// The mapping isn't part of the recovery file, see SigningKeys() for persisting it.
+func (w *Wallet) SetSigningKey(addr libra.AccountAddress, index uint64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.signingKeys == nil {
+		w.signingKeys = make(map[libra.AccountAddress]uint64)
+	}
+	w.signingKeys[addr] = index
+}
+
+// SigningKeys returns the indexes of the accounts whose keys were set as signing keys with SetSigningKey(...), by address.
+func (w *Wallet) SigningKeys() map[libra.AccountAddress]uint64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	result := make(map[libra.AccountAddress]uint64, len(w.signingKeys))
+	for addr, index := range w.signingKeys {
+		result[addr] = index
+	}
+	return result
+}
+
+// Signer returns a signer for the account with the given address.
+// It signs with the key that was set with SetSigningKey(...), or else with the key of the wallet's account with that address.
+// ErrUnknownAccount is returned if the wallet has neither.
+func (w *Wallet) Signer(addr libra.AccountAddress) (libra.AccountSigner, error) {
+	w.lock.Lock()
+	index, ok := w.signingKeys[addr]
+	w.lock.Unlock()
+	if ok {
+		acc, err := w.Account(index)
+		if err != nil {
+			return libra.AccountSigner{}, err
+		}
+		return libra.AccountSigner{Signer: acc, Address: addr}, nil
+	}
+
+	accs, err := w.Accounts()
+	if err != nil {
+		return libra.AccountSigner{}, err
+	}
+	for _, acc := range accs {
+		if acc.Address == addr {
+			return libra.AccountSigner{Signer: acc, Address: addr}, nil
+		}
+	}
+	return libra.AccountSigner{}, ErrUnknownAccount
+}
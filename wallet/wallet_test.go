@@ -0,0 +1,134 @@
+package wallet_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/wallet"
+)
+
+const testMnemonic = "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title"
+
+// TestFromMnemonic tests if the same mnemonic leads to the same accounts.
+func TestFromMnemonic(t *testing.T) {
+	w1, err := wallet.FromMnemonic(testMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := wallet.FromMnemonic(testMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc1, err := w1.Account(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc2, err := w2.Account(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc1.Address != acc2.Address {
+		t.Fatalf("Expected the same address, but was %v and %v", acc1.Address, acc2.Address)
+	}
+	acc0, err := w1.Account(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc0.Address == acc1.Address {
+		t.Fatal("Expected different addresses for different indexes")
+	}
+
+	if _, err := wallet.FromMnemonic("legal winner thank"); err == nil {
+		t.Fatal("Expected an error for an invalid mnemonic")
+	}
+}
+
+// TestRecover tests if a wallet can be recovered from its recovery file.
+func TestRecover(t *testing.T) {
+	w, err := wallet.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := w.NewAccount(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dir, err := ioutil.TempDir("", "wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "recovery")
+	if err := w.WriteRecovery(path); err != nil {
+		t.Fatal(err)
+	}
+
+	recovered, err := wallet.Recover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered.Mnemonic() != w.Mnemonic() {
+		t.Fatalf("Expected mnemonic %q, but was %q", w.Mnemonic(), recovered.Mnemonic())
+	}
+	expected, err := w.Accounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts, err := recovered.Accounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != len(expected) {
+		t.Fatalf("Expected %v accounts, but got %v", len(expected), len(accounts))
+	}
+	for i := range accounts {
+		if accounts[i].Address != expected[i].Address {
+			t.Fatalf("Expected address %v, but was %v", expected[i].Address, accounts[i].Address)
+		}
+	}
+}
+
+// TestSigner tests if the wallet's signer for an account uses the signing key that was set for it.
+func TestSigner(t *testing.T) {
+	w, err := wallet.FromMnemonic(testMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := w.NewAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKeyAcc, err := w.Account(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := w.Signer(acc.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(signer.PublicKey(), acc.PublicKey()) {
+		t.Fatal("Expected the signer to use the account's own key")
+	}
+
+	w.SetSigningKey(acc.Address, newKeyAcc.Index)
+	signer, err = w.Signer(acc.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(signer.PublicKey(), newKeyAcc.PublicKey()) || libra.SenderOf(signer) != acc.Address {
+		t.Fatal("Expected the signer to use the rotated key for the account's address")
+	}
+	if keys := w.SigningKeys(); len(keys) != 1 || keys[acc.Address] != 5 {
+		t.Fatalf("Expected one signing key with index 5, but was %v", keys)
+	}
+
+	if _, err := w.Signer(libra.AccountAddress{3}); err != wallet.ErrUnknownAccount {
+		t.Fatalf("Expected %v, but was %v", wallet.ErrUnknownAccount, err)
+	}
+}
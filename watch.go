@@ -0,0 +1,211 @@
+package libra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// watchEventLimit is the maximum number of sent and received events that are requested per poll of WatchAccount(...)
+const watchEventLimit = 100
+
+// AccountCursor is the position of an account watcher.
+// Store the cursor of the latest update to resume watching from it with Client.WatchAccountFrom(...).
+type AccountCursor struct {
+	// Version of the ledger at which the account state was read
+	Version    uint64
+	SequenceNo uint64
+	Balance    Amount
+	// SentEvents and ReceivedEvents are the numbers of events that were already emitted,
+	// which are the sequence numbers of the next events
+	SentEvents     uint64
+	ReceivedEvents uint64
+}
+
+// AccountUpdate is a change of a watched account.
+type AccountUpdate struct {
+	// Version of the ledger at which the account state was read
+	Version    uint64
+	SequenceNo uint64
+	Balance    Amount
+	// PreviousBalance is the balance of the previous update, or of the cursor the watcher was started with
+	PreviousBalance Amount
+	// SentEvents and ReceivedEvents are the new payment events since the previous update.
+	// Use Event.PaymentEvent() to decode them.
+	SentEvents     []Event
+	ReceivedEvents []Event
+	// Cursor for resuming after this update
+	Cursor AccountCursor
+	// Err is set if polling failed. All other fields are empty then and the watcher keeps polling.
+	Err error
+}
+
+// BalanceDelta returns the change of the balance since the previous update in micro-libra.
+func (u AccountUpdate) BalanceDelta() int64 {
+	return int64(u.Balance) - int64(u.PreviousBalance)
+}
+
+// WatchAccount polls the state and the sent and received payment events of the given account
+// and sends an update to the returned channel whenever they change.
+// The first update is sent for the first change after the call.
+// The channel is closed when the context is done.
+//
+// An account that doesn't exist yet is treated like an empty account, so its creation leads to an update.
+func (c Client) WatchAccount(ctx context.Context, addr AccountAddress) <-chan AccountUpdate {
+	return c.watchAccount(ctx, addr, nil)
+}
+
+// WatchAccountFrom is like WatchAccount(...), but starts at the given cursor,
+// e.g. the cursor of the last update that was processed before a restart.
+// All events since the cursor are sent with the first update.
+// Use the zero value for getting all events of the account.
+func (c Client) WatchAccountFrom(ctx context.Context, addr AccountAddress, cursor AccountCursor) <-chan AccountUpdate {
+	return c.watchAccount(ctx, addr, &cursor)
+}
+
+func (c Client) watchAccount(ctx context.Context, addr AccountAddress, cursor *AccountCursor) <-chan AccountUpdate {
+	updates := make(chan AccountUpdate)
+	go func() {
+		defer close(updates)
+		interval := minPollInterval
+		for {
+			update, more, err := c.pollAccount(ctx, addr, cursor)
+			if err != nil {
+				update = AccountUpdate{Err: err}
+			}
+			changed := err == nil && update.changedSince(cursor)
+			if err != nil || changed {
+				select {
+				case <-ctx.Done():
+					return
+				case updates <- update:
+				}
+			}
+			if changed {
+				interval = minPollInterval
+			}
+			if err == nil {
+				cursor = &update.Cursor
+			}
+			// Without waiting if not all new events could be fetched, but only if the poll advanced the cursor.
+			// A node can have higher event counters than events it returns, e.g. a lagging or pruning full node,
+			// which would otherwise be polled without any pause.
+			if more && len(update.SentEvents)+len(update.ReceivedEvents) > 0 {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			interval = interval * 3 / 2
+			if interval > maxPollInterval {
+				interval = maxPollInterval
+			}
+		}
+	}()
+	return updates
+}
+
+// changedSince returns true if the update contains any change compared to the cursor.
+// Without a cursor there's nothing to compare to, so false is returned.
+func (u AccountUpdate) changedSince(cursor *AccountCursor) bool {
+	if cursor == nil {
+		return false
+	}
+	return u.SequenceNo != cursor.SequenceNo || u.Balance != cursor.Balance || len(u.SentEvents) > 0 || len(u.ReceivedEvents) > 0
+}
+
+// pollAccount requests the account state and the events since the cursor in a single request.
+// If the cursor is nil, no events are requested and the returned update only contains the new cursor.
+// more is true if there are more events than could be fetched.
+func (c Client) pollAccount(ctx context.Context, addr AccountAddress, cursor *AccountCursor) (update AccountUpdate, more bool, err error) {
+	requestItems := []*types.RequestItem{
+		{
+			RequestedItems: &types.RequestItem_GetAccountStateRequest{
+				GetAccountStateRequest: &types.GetAccountStateRequest{
+					Address: addr.Bytes(),
+				},
+			},
+		},
+	}
+	req := &types.UpdateToLatestLedgerRequest{}
+	if cursor != nil {
+		req.ClientKnownVersion = cursor.Version
+		requestItems = append(requestItems,
+			eventsRequestItem(accesspath.SentEvents(addr.Bytes()), cursor.SentEvents),
+			eventsRequestItem(accesspath.ReceivedEvents(addr.Bytes()), cursor.ReceivedEvents),
+		)
+	}
+	req.RequestedItems = requestItems
+	res, err := c.acc.UpdateToLatestLedger(ctx, req)
+	if err != nil {
+		return AccountUpdate{}, false, err
+	}
+	responseItems := res.GetResponseItems()
+	if len(responseItems) != len(requestItems) {
+		return AccountUpdate{}, false, fmt.Errorf("Expected %v response items, but got %v", len(requestItems), len(responseItems))
+	}
+
+	accStateWithProof := responseItems[0].GetGetAccountStateResponse().GetAccountStateWithProof()
+	var accRes AccountResource
+	if blob := accStateWithProof.GetBlob().GetBlob(); len(blob) > 0 {
+		accState, err := FromAccountStateBlob(blob)
+		if err != nil {
+			return AccountUpdate{}, false, err
+		}
+		accRes = accState.AccountResource
+	}
+	update = AccountUpdate{
+		Version:         res.GetLedgerInfoWithSigs().GetLedgerInfo().GetVersion(),
+		SequenceNo:      accRes.SequenceNo,
+		Balance:         accRes.Balance,
+		PreviousBalance: accRes.Balance,
+		Cursor: AccountCursor{
+			SequenceNo:     accRes.SequenceNo,
+			Balance:        accRes.Balance,
+			SentEvents:     accRes.SentEvents,
+			ReceivedEvents: accRes.ReceivedEvents,
+		},
+	}
+	update.Cursor.Version = update.Version
+	if cursor == nil {
+		return update, false, nil
+	}
+
+	update.PreviousBalance = cursor.Balance
+	update.SentEvents = eventsFromResponseItem(responseItems[1])
+	update.ReceivedEvents = eventsFromResponseItem(responseItems[2])
+	// The cursor only advances by the events that were fetched
+	update.Cursor.SentEvents = nextEventSeqNo(cursor.SentEvents, update.SentEvents)
+	update.Cursor.ReceivedEvents = nextEventSeqNo(cursor.ReceivedEvents, update.ReceivedEvents)
+	more = update.Cursor.SentEvents < accRes.SentEvents || update.Cursor.ReceivedEvents < accRes.ReceivedEvents
+	return update, more, nil
+}
+
+// nextEventSeqNo returns the sequence number of the event after the given events,
+// or start if there are no events.
+func nextEventSeqNo(start uint64, events []Event) uint64 {
+	if len(events) == 0 {
+		return start
+	}
+	return events[len(events)-1].SequenceNo + 1
+}
+
+// eventsRequestItem requests the events of an event handle in ascending order, starting with the given sequence number.
+func eventsRequestItem(accessPath *types.AccessPath, start uint64) *types.RequestItem {
+	return &types.RequestItem{
+		RequestedItems: &types.RequestItem_GetEventsByEventAccessPathRequest{
+			GetEventsByEventAccessPathRequest: &types.GetEventsByEventAccessPathRequest{
+				AccessPath:       accessPath,
+				StartEventSeqNum: start,
+				Ascending:        true,
+				Limit:            watchEventLimit,
+			},
+		},
+	}
+}
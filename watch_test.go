@@ -0,0 +1,124 @@
+package libra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	libra "github.com/philippgille/libra-sdk-go"
+)
+
+// newTestTransfer creates an unsigned peer-to-peer transfer.
+// The transfer script is registered with the same code as in TestLoadScripts.
+func newTestTransfer(t *testing.T, sender libra.AccountAddress, seqNo uint64, receiver libra.AccountAddress, amount libra.Amount) libra.Transaction {
+	libra.RegisterScript(libra.Script{
+		Name: libra.ScriptPeerToPeerTransfer,
+		Code: []byte{1, 2, 3},
+	})
+	rawTx, err := libra.NewTransferTransaction(sender, seqNo, receiver, amount, libra.DefaultFeePolicy.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return libra.Transaction{
+		RawBytes: rawTxBytes,
+	}
+}
+
+// receiveUpdate receives the next account update and fails the test if it contains an error.
+func receiveUpdate(t *testing.T, updates <-chan libra.AccountUpdate) libra.AccountUpdate {
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("The update channel was closed")
+		}
+		if update.Err != nil {
+			t.Fatal(update.Err)
+		}
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("No account update was received")
+	}
+	return libra.AccountUpdate{}
+}
+
+// TestWatchAccount tests if payments lead to account updates with the payment events
+// and if watching can be resumed from a cursor.
+func TestWatchAccount(t *testing.T) {
+	s, c, sender := newTestServerAndClient(t, libra.AccountResource{Balance: 100 * libra.Libra})
+	defer s.Close()
+	defer c.Close()
+	receiver := libra.AccountAddress{2}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	senderUpdates := c.WatchAccount(ctx, sender)
+	// The first poll must happen before the transfer
+	time.Sleep(200 * time.Millisecond)
+	receiverUpdates := c.WatchAccountFrom(ctx, receiver, libra.AccountCursor{})
+	if err := c.SendTx(newTestTransfer(t, sender, 0, receiver, 5*libra.Libra)); err != nil {
+		t.Fatal(err)
+	}
+
+	update := receiveUpdate(t, receiverUpdates)
+	if update.BalanceDelta() != int64(5*libra.Libra) {
+		t.Fatalf("Expected a balance delta of %v, but was %v", 5*libra.Libra, update.BalanceDelta())
+	}
+	if len(update.ReceivedEvents) != 1 || len(update.SentEvents) != 0 {
+		t.Fatalf("Expected 1 received event, but got %v received and %v sent events", len(update.ReceivedEvents), len(update.SentEvents))
+	}
+	payment, err := update.ReceivedEvents[0].PaymentEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payment.Amount != 5*libra.Libra || payment.Counterparty != sender {
+		t.Fatalf("Expected a payment of 5 LBR from %v, but was %v from %v", sender, payment.Amount, payment.Counterparty)
+	}
+
+	update = receiveUpdate(t, senderUpdates)
+	if update.SequenceNo != 1 || len(update.SentEvents) != 1 {
+		t.Fatalf("Expected sequence number 1 and 1 sent event, but was %v and %v", update.SequenceNo, len(update.SentEvents))
+	}
+	// The default gas unit price is 0, so there's no fee
+	if expected := -int64(5 * libra.Libra); update.BalanceDelta() != expected {
+		t.Fatalf("Expected a balance delta of %v, but was %v", expected, update.BalanceDelta())
+	}
+
+	// Resuming from the cursor only returns the new payment
+	cursor := update.Cursor
+	cancel()
+	if err := c.SendTx(newTestTransfer(t, sender, 1, receiver, 2*libra.Libra)); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	update = receiveUpdate(t, c.WatchAccountFrom(ctx, sender, cursor))
+	if len(update.SentEvents) != 1 || update.SentEvents[0].SequenceNo != 1 {
+		t.Fatalf("Expected only the sent event with sequence number 1, but got %v events", len(update.SentEvents))
+	}
+}
+
+// TestWatchAccountMissingEvents tests if a node that doesn't return the events for the account's event counters
+// is polled with the backoff instead of continuously.
+func TestWatchAccountMissingEvents(t *testing.T) {
+	s, c, addr := newTestServerAndClient(t, libra.AccountResource{SentEvents: 3, ReceivedEvents: 2})
+	defer s.Close()
+	defer c.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := c.WatchAccountFrom(ctx, addr, libra.AccountCursor{})
+	time.Sleep(500 * time.Millisecond)
+	// With the backoff starting at 100ms, there are about 4 polls
+	if n := s.LedgerRequests(); n > 10 {
+		t.Fatalf("Expected at most 10 requests, but the node was polled %v times", n)
+	}
+	select {
+	case update := <-updates:
+		t.Fatalf("Expected no update without events, but got %+v", update)
+	default:
+	}
+}
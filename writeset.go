@@ -0,0 +1,192 @@
+package libra
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/internal/canonical"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// Variant indexes of the write op types in Libra's canonical serialization.
+// They differ from the protobuf enum.
+const (
+	writeOpDeletion uint32 = 0
+	writeOpValue    uint32 = 1
+)
+
+// WriteSet is the payload of a write set transaction, which directly writes to and deletes from the global storage.
+// Only genesis and privileged accounts can send write set transactions,
+// e.g. on private networks. Build it with Write(...) and Delete(...).
+type WriteSet struct {
+	Ops []WriteOp
+}
+
+// WriteOp writes a value to an access path or deletes the value at an access path.
+type WriteOp struct {
+	AccessPath *types.AccessPath
+	Type       types.WriteOpType
+	// Value is the written value. It's empty for deletions.
+	Value []byte
+}
+
+// DuplicateAccessPathError is returned when multiple ops of a write set have the same access path.
+// The Libra VM rejects such write sets with the validation status InvalidWriteSet.
+type DuplicateAccessPathError struct {
+	// Index of the op with the duplicate access path
+	Index int
+	// PreviousIndex is the index of the first op with the same access path
+	PreviousIndex int
+	AccessPath    *types.AccessPath
+}
+
+// Error implements the error interface.
+func (e DuplicateAccessPathError) Error() string {
+	return fmt.Sprintf("The write ops %v and %v have the same access path %v", e.PreviousIndex, e.Index, formatAccessPath(e.AccessPath))
+}
+
+// Write adds an op that writes the value to the access path.
+// The write set is returned for chaining calls.
+func (ws *WriteSet) Write(ap *types.AccessPath, value []byte) *WriteSet {
+	ws.Ops = append(ws.Ops, WriteOp{
+		AccessPath: ap,
+		Type:       types.WriteOpType_Write,
+		Value:      value,
+	})
+	return ws
+}
+
+// Delete adds an op that deletes the value at the access path.
+// The write set is returned for chaining calls.
+func (ws *WriteSet) Delete(ap *types.AccessPath) *WriteSet {
+	ws.Ops = append(ws.Ops, WriteOp{
+		AccessPath: ap,
+		Type:       types.WriteOpType_Delete,
+	})
+	return ws
+}
+
+// Validate checks if the addresses of the access paths are valid, if deletions don't have a value
+// and if there are no duplicate access paths (DuplicateAccessPathError).
+func (ws WriteSet) Validate() error {
+	if len(ws.Ops) == 0 {
+		return errors.New("The write set is empty")
+	}
+	seen := make(map[string]int, len(ws.Ops))
+	for i, op := range ws.Ops {
+		if _, err := AccountAddressFromBytes(op.AccessPath.GetAddress()); err != nil {
+			return fmt.Errorf("Invalid access path of write op %v: %v", i, err)
+		}
+		if op.Type == types.WriteOpType_Delete && len(op.Value) != 0 {
+			return fmt.Errorf("The write op %v is a deletion, but has a value", i)
+		}
+		key := string(op.AccessPath.GetAddress()) + string(op.AccessPath.GetPath())
+		if previous, ok := seen[key]; ok {
+			return DuplicateAccessPathError{
+				Index:         i,
+				PreviousIndex: previous,
+				AccessPath:    op.AccessPath,
+			}
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// NewWriteSetTransaction creates a raw transaction with the write set as payload.
+// The write set is validated, see WriteSet.Validate().
+func NewWriteSetTransaction(sender AccountAddress, seqNo uint64, ws WriteSet) (RawTransaction, error) {
+	if err := ws.Validate(); err != nil {
+		return RawTransaction{}, err
+	}
+	return RawTransaction{
+		Sender:     sender,
+		SequenceNo: seqNo,
+		WriteSet:   &ws,
+	}, nil
+}
+
+// toProto converts the write set into the WriteSet of Libra's gRPC API.
+func (ws WriteSet) toProto() *types.WriteSet {
+	result := &types.WriteSet{}
+	for _, op := range ws.Ops {
+		result.WriteSet = append(result.WriteSet, &types.WriteOp{
+			AccessPath: op.AccessPath,
+			Type:       op.Type,
+			Value:      op.Value,
+		})
+	}
+	return result
+}
+
+// writeSetFromProto converts the WriteSet of Libra's gRPC API into a WriteSet.
+func writeSetFromProto(ws *types.WriteSet) *WriteSet {
+	result := &WriteSet{}
+	for _, op := range ws.GetWriteSet() {
+		result.Ops = append(result.Ops, WriteOp{
+			AccessPath: op.GetAccessPath(),
+			Type:       op.GetType(),
+			Value:      op.GetValue(),
+		})
+	}
+	return result
+}
+
+// serialize writes the write set in Libra's canonical serialization.
+func (ws WriteSet) serialize(s *canonical.Serializer) error {
+	s.U32(uint32(len(ws.Ops)))
+	for _, op := range ws.Ops {
+		s.Bytes(op.AccessPath.GetAddress()).Bytes(op.AccessPath.GetPath())
+		switch op.Type {
+		case types.WriteOpType_Write:
+			s.U32(writeOpValue).Bytes(op.Value)
+		case types.WriteOpType_Delete:
+			s.U32(writeOpDeletion)
+		default:
+			return fmt.Errorf("Unknown write op type: %v", op.Type)
+		}
+	}
+	return nil
+}
+
+// DecodedWriteOp is a write op with its parsed access path and decoded value.
+type DecodedWriteOp struct {
+	WriteOp
+	// Path is the parsed access path. It's nil if the access path can't be parsed.
+	Path *accesspath.Path
+	// DecodedValue is the AccountResource for writes of account resources and nil otherwise.
+	DecodedValue interface{}
+}
+
+// Decode parses the access paths of the write set's ops and decodes their values, if they're account resources.
+// Values that can't be decoded are left as they are.
+func (ws WriteSet) Decode() []DecodedWriteOp {
+	var result []DecodedWriteOp
+	for _, op := range ws.Ops {
+		decodedOp := DecodedWriteOp{
+			WriteOp: op,
+		}
+		if path, err := accesspath.Parse(op.AccessPath); err == nil {
+			decodedOp.Path = &path
+			isAccountResource := path.Tag == accesspath.ResourceTag && path.Suffix == "" &&
+				bytes.Equal(path.Hash, accesspath.AccountResourceTag.Hash())
+			if isAccountResource && op.Type == types.WriteOpType_Write {
+				if accRes, err := FromAccountResourceBlob(op.Value); err == nil {
+					decodedOp.DecodedValue = accRes
+				}
+			}
+		}
+		result = append(result, decodedOp)
+	}
+	return result
+}
+
+// formatAccessPath formats an access path in its parsed human-readable form, or as hex if it can't be parsed.
+func formatAccessPath(ap *types.AccessPath) string {
+	if path, err := accesspath.Parse(ap); err == nil {
+		return path.String()
+	}
+	return fmt.Sprintf("0x%x/0x%x", ap.GetAddress(), ap.GetPath())
+}
@@ -0,0 +1,111 @@
+package libra_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	libra "github.com/philippgille/libra-sdk-go"
+	"github.com/philippgille/libra-sdk-go/accesspath"
+	"github.com/philippgille/libra-sdk-go/rpc/types"
+)
+
+// TestWriteSetTransaction tests if write set transactions can be built, encoded, hashed, summarized and decoded.
+func TestWriteSetTransaction(t *testing.T) {
+	addr := libra.AccountAddress{1}
+	accRes := libra.AccountResource{
+		AuthKey:    addr.Bytes(),
+		Balance:    5 * libra.Libra,
+		SequenceNo: 2,
+	}
+	ws := &libra.WriteSet{}
+	ws.Write(accesspath.Resource(addr.Bytes(), accesspath.AccountResourceTag), accRes.ToBlob()).
+		Delete(accesspath.Resource(libra.AccountAddress{2}.Bytes(), accesspath.AccountResourceTag))
+	rawTx, err := libra.NewWriteSetTransaction(libra.AssociationAddress, 0, *ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := rawTx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawTxBytes, err := rawTx.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedRawTx, err := libra.RawTransactionFromBytes(rawTxBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decodedHash, err := decodedRawTx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decodedHash, hash) {
+		t.Fatal("The decoded raw transaction differs from the encoded one")
+	}
+
+	summary, err := rawTx.Summary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary.WriteSet) != 2 || !strings.HasPrefix(summary.WriteSet[0], "write ") || !strings.Contains(summary.WriteSet[0], "/resource/LibraAccount.T") {
+		t.Fatalf("Unexpected write set summary: %v", summary.WriteSet)
+	}
+
+	// The summary survives the JSON round trip of offline signing
+	unsignedTx, err := libra.NewUnsignedTransaction(rawTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(unsignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &unsignedTx); err != nil {
+		t.Fatal(err)
+	}
+
+	decodedTx, err := libra.Transaction{RawBytes: rawTxBytes}.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decodedTx.WriteSet) != 2 {
+		t.Fatalf("Expected 2 decoded write ops, but got %v", len(decodedTx.WriteSet))
+	}
+	op := decodedTx.WriteSet[0]
+	if op.Path == nil || op.Path.Resource == nil || op.Path.Resource.String() != "LibraAccount.T" {
+		t.Fatalf("Expected the path of the account resource, but was %v", op.Path)
+	}
+	if diff := deep.Equal(op.DecodedValue, accRes); diff != nil {
+		t.Fatal(diff)
+	}
+	if decodedTx.WriteSet[1].Type != types.WriteOpType_Delete || decodedTx.WriteSet[1].DecodedValue != nil {
+		t.Fatal("Expected a deletion without value")
+	}
+	b, err = json.Marshal(decodedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"account_resource":{`) {
+		t.Fatalf("Expected the decoded account resource in the JSON encoding: %s", b)
+	}
+}
+
+// TestWriteSetDuplicateAccessPath tests if write sets with duplicate access paths are rejected.
+func TestWriteSetDuplicateAccessPath(t *testing.T) {
+	ap := accesspath.Resource(libra.AccountAddress{1}.Bytes(), accesspath.AccountResourceTag)
+	ws := &libra.WriteSet{}
+	ws.Write(accesspath.SentEvents(libra.AccountAddress{1}.Bytes()), []byte{1}).Write(ap, []byte{2}).Delete(ap)
+	_, err := libra.NewWriteSetTransaction(libra.AssociationAddress, 0, *ws)
+	dupErr, ok := err.(libra.DuplicateAccessPathError)
+	if !ok {
+		t.Fatalf("Expected a DuplicateAccessPathError, but was %v", err)
+	}
+	if dupErr.PreviousIndex != 1 || dupErr.Index != 2 {
+		t.Fatalf("Expected the duplicate indexes 1 and 2, but were %v and %v", dupErr.PreviousIndex, dupErr.Index)
+	}
+}